@@ -4,20 +4,29 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/carlos/mcp-repo-monitor/config"
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
 	"github.com/carlos/mcp-repo-monitor/internal/application/usecase"
 	"github.com/carlos/mcp-repo-monitor/internal/domain/service"
 	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/cache"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/gitea"
 	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/github"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/gitlab"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/localgit"
 	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
 	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/mcp"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/routing"
 )
 
 func main() {
 	mode := flag.String("mode", "stdio", "Server mode: stdio or sse")
 	addr := flag.String("addr", ":8080", "Address for SSE server")
 	logLevel := flag.String("log-level", "info", "Log level: debug, info, warn, error")
+	cacheBackend := flag.String("cache", "file", "Cache backend: file (persists across restarts) or memory")
+	staleWindow := flag.Duration("stale-window", 0, "Serve expired cache entries up to this long while refreshing them in the background (0 disables)")
+	concurrency := flag.Int("concurrency", usecase.DefaultStatusConcurrency, "Repositories checked in parallel by repo_list_status")
 	flag.Parse()
 
 	logger := logging.New(logging.ParseLevel(*logLevel))
@@ -28,31 +37,72 @@ func main() {
 		os.Exit(1)
 	}
 
-	if cfg.GitHubToken == "" {
-		fmt.Fprintln(os.Stderr, "GITHUB_TOKEN environment variable is required")
+	gitLabRepos := cfg.RepositoriesOn(config.ProviderGitLab)
+	giteaRepos := cfg.RepositoriesOn(config.ProviderGitea)
+	localRepos := cfg.RepositoriesOn(config.ProviderLocal)
+	otherRepos := len(gitLabRepos) + len(giteaRepos) + len(localRepos)
+	if cfg.GitHubToken == "" && cfg.GitHubApp == nil && len(cfg.Accounts) == 0 && otherRepos == 0 {
+		fmt.Fprintln(os.Stderr, "GITHUB_TOKEN or GITHUB_APP_ID environment variable is required")
+		os.Exit(1)
+	}
+	if len(gitLabRepos) > 0 && cfg.GitLab.Token == "" {
+		fmt.Fprintln(os.Stderr, "GITLAB_TOKEN environment variable is required for repositories with provider gitlab")
+		os.Exit(1)
+	}
+	if len(giteaRepos) > 0 && (cfg.Gitea.URL == "" || cfg.Gitea.Token == "") {
+		fmt.Fprintln(os.Stderr, "GITEA_URL and GITEA_TOKEN environment variables are required for repositories with provider gitea")
+		os.Exit(1)
+	}
+	if len(localRepos) > 0 && cfg.LocalGit.Root == "" {
+		fmt.Fprintln(os.Stderr, "LOCAL_GIT_ROOT environment variable is required for repositories with provider local")
 		os.Exit(1)
 	}
-
-	ghClient := github.NewClient(cfg.GitHubToken, logger)
 
 	// Create cache for frequently accessed data
-	apiCache := cache.New(cache.DefaultConfig())
-	cachedClient := github.NewCachedClient(ghClient, apiCache, logger)
+	memoryConfig := cache.DefaultConfig()
+	memoryConfig.StaleRetention = *staleWindow
+	memoryConfig.PrefixTTLs = github.CacheTTLs()
+	fileConfig := cache.DefaultFileConfig()
+	fileConfig.StaleRetention = *staleWindow
+	fileConfig.PrefixTTLs = github.CacheTTLs()
+	apiCache := newCache(*cacheBackend, memoryConfig, fileConfig, logger)
+
+	// The responses the client revalidates with conditional requests are kept
+	// for a day, so they get their own store and size budget instead of
+	// evicting the repository and PR listings.
+	responseMemoryConfig := cache.DefaultConfig()
+	responseMemoryConfig.MaxBytes = 16 << 20
+	responseFileConfig := cache.DefaultFileConfig()
+	responseFileConfig.Dir = filepath.Join(responseFileConfig.Dir, "responses")
+	responseFileConfig.MaxBytes = 25 << 20
+	responseCache := newCache(*cacheBackend, responseMemoryConfig, responseFileConfig, logger)
+
+	ghClient, err := newRouter(cfg, responseCache, logger)
+	if err != nil {
+		logger.Error("failed to create client", "error", err)
+		os.Exit(1)
+	}
+	cachedClient := github.NewCachedClient(ghClient, apiCache, *staleWindow, logger).WithResponseStore(responseCache)
 
 	driftDetector := service.NewDriftDetector()
 	rollbackService := service.NewRollbackService()
+	healthScorer := service.NewHealthScorer()
 
-	// Use cached client for read-heavy use cases
-	listStatus := usecase.NewListStatusUseCase(cachedClient)
+	// Read use cases go through the cached client, which only caches
+	// repository and PR listings; CI runs, commits and comparisons stay
+	// real-time. Tools can ask for fresher data with fresh or max_age.
+	listStatus := usecase.NewListStatusUseCase(cachedClient, cfg, driftDetector, healthScorer, *concurrency)
 	listPRs := usecase.NewListPRsUseCase(cachedClient)
-	checkCI := usecase.NewCheckCIUseCase(ghClient) // CI status should be real-time
-	triggerRollback := usecase.NewTriggerRollbackUseCase(ghClient, rollbackService)
-	recentCommits := usecase.NewRecentCommitsUseCase(ghClient) // Commits should be real-time
-	checkDrift := usecase.NewCheckDriftUseCase(ghClient, cfg, driftDetector) // Drift needs real-time
-	createSyncPR := usecase.NewCreateSyncPRUseCase(ghClient, cfg)
-	createPR := usecase.NewCreatePRUseCase(ghClient)
-	mergePR := usecase.NewMergePRUseCase(ghClient)
-	deleteBranch := usecase.NewDeleteBranchUseCase(ghClient)
+	checkCI := usecase.NewCheckCIUseCase(cachedClient)
+	triggerRollback := usecase.NewTriggerRollbackUseCase(ghClient, cfg, rollbackService, cachedClient)
+	recentCommits := usecase.NewRecentCommitsUseCase(cachedClient)
+	checkDrift := usecase.NewCheckDriftUseCase(cachedClient, cfg, driftDetector)
+	createSyncPR := usecase.NewCreateSyncPRUseCase(ghClient, cfg, cachedClient)
+	createPR := usecase.NewCreatePRUseCase(ghClient, cachedClient)
+	mergePR := usecase.NewMergePRUseCase(ghClient, cachedClient)
+	deleteBranch := usecase.NewDeleteBranchUseCase(ghClient, cachedClient)
+	serverStatus := usecase.NewServerStatusUseCase(ghClient, ghClient, cachedClient)
+	clearCache := usecase.NewClearCacheUseCase(cachedClient)
 
 	presenter := mcp.NewPresenter()
 	handler := mcp.NewHandler(
@@ -66,6 +116,8 @@ func main() {
 		createPR,
 		mergePR,
 		deleteBranch,
+		serverStatus,
+		clearCache,
 		presenter,
 	)
 	server := mcp.NewServer(handler)
@@ -85,3 +137,96 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// newRouter creates a client per configured account and provider, and routes
+// calls between them. Repositories configured with provider gitlab, gitea or
+// local go to that provider; the others by owner to the GitHub accounts, with the
+// credentials from the environment serving every owner no account matches.
+// Local repositories are read from their mirror and, when a GitHub account
+// serves their owner, use it for everything a mirror cannot answer.
+func newRouter(cfg *config.Config, httpCache cache.Store, logger *logging.Logger) (*routing.Router, error) {
+	endpoints := github.Endpoints{
+		APIURL:    cfg.GitHub.APIURL,
+		UploadURL: cfg.GitHub.UploadURL,
+		WebURL:    cfg.GitHub.WebURL,
+	}
+
+	accounts := cfg.Accounts
+	if cfg.GitHubToken != "" || cfg.GitHubApp != nil {
+		accounts = append(accounts[:len(accounts):len(accounts)], config.Account{
+			Name:   "default",
+			Owners: []string{"*"},
+			Token:  cfg.GitHubToken,
+			App:    cfg.GitHubApp,
+		})
+	}
+
+	routes := make([]routing.Account, 0, len(accounts)+3)
+	if gitLabRepos := cfg.RepositoriesOn(config.ProviderGitLab); len(gitLabRepos) > 0 {
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token, logger)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, routing.Account{Name: config.ProviderGitLab, Repositories: gitLabRepos, Client: client})
+	}
+	if giteaRepos := cfg.RepositoriesOn(config.ProviderGitea); len(giteaRepos) > 0 {
+		client, err := gitea.NewClient(cfg.Gitea.URL, cfg.Gitea.Token, logger)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, routing.Account{Name: config.ProviderGitea, Repositories: giteaRepos, Client: client})
+	}
+
+	gitHubRoutes := make([]routing.Account, 0, len(accounts))
+	for _, account := range accounts {
+		creds := github.Credentials{Account: account.Name, Token: account.Token}
+		if account.App != nil {
+			creds.App = &github.AppCredentials{
+				AppID:          account.App.AppID,
+				PrivateKey:     account.App.PrivateKey,
+				InstallationID: account.App.InstallationID,
+			}
+		}
+
+		client, err := github.NewClient(creds, endpoints, httpCache, logger)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", account.Name, err)
+		}
+		gitHubRoutes = append(gitHubRoutes, routing.Account{Name: account.Name, Owners: account.Owners, Client: client})
+	}
+
+	if localRepos := cfg.RepositoriesOn(config.ProviderLocal); len(localRepos) > 0 {
+		mirror, err := localgit.NewClient(cfg.LocalGit.Root, logger)
+		if err != nil {
+			return nil, err
+		}
+		var client port.GitHubClient = mirror
+		if len(gitHubRoutes) > 0 {
+			client = localgit.NewMirrored(mirror, routing.NewRouter(gitHubRoutes, logger))
+		}
+		routes = append(routes, routing.Account{Name: config.ProviderLocal, Repositories: localRepos, Client: client})
+	}
+	routes = append(routes, gitHubRoutes...)
+
+	return routing.NewRouter(routes, logger), nil
+}
+
+// newCache creates the cache backend, falling back to memory when the file
+// cache cannot be opened.
+func newCache(backend string, memoryConfig cache.Config, fileConfig cache.FileConfig, logger *logging.Logger) cache.Store {
+	switch backend {
+	case "memory":
+		return cache.New(memoryConfig)
+	case "file":
+		fileCache, err := cache.NewFile(fileConfig, logger)
+		if err != nil {
+			logger.Warn("file cache unavailable, using memory cache", "error", err)
+			return cache.New(memoryConfig)
+		}
+		return fileCache
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown cache backend: %s\n", backend)
+		os.Exit(1)
+		return nil
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// ErrInvalidAccount is returned for an invalid entry in accounts.json.
+var ErrInvalidAccount = errors.New("invalid account configuration")
+
+// Account is a named set of GitHub credentials serving the repositories of
+// the owners matching its patterns. Accounts are matched in order; owners no
+// account matches use GITHUB_TOKEN or the GitHub App from the environment.
+type Account struct {
+	Name   string
+	Owners []string // Owner patterns in path.Match syntax, e.g. acme or acme-*
+	Token  string
+	App    *GitHubApp // Used instead of Token when set
+}
+
+// accountsFile is the layout of accounts.json. Secrets are not stored in the
+// file itself: tokens are read from the named environment variable and app
+// keys from the named file.
+type accountsFile struct {
+	Accounts []struct {
+		Name     string   `json:"name"`
+		Owners   []string `json:"owners"`
+		TokenEnv string   `json:"token_env,omitempty"`
+		App      *struct {
+			AppID          int64  `json:"app_id"`
+			PrivateKeyPath string `json:"private_key_path"`
+			InstallationID int64  `json:"installation_id,omitempty"`
+		} `json:"app,omitempty"`
+	} `json:"accounts"`
+}
+
+// loadAccounts reads ~/.mcp-repo-monitor/accounts.json. A missing file means
+// no extra accounts.
+func loadAccounts(logger *logging.Logger) ([]Account, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, nil
+	}
+
+	configPath := filepath.Join(homeDir, ".mcp-repo-monitor", "accounts.json")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logger.Debug("accounts.json not found, using environment credentials only", "path", configPath)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read accounts.json: %w", err)
+	}
+
+	var file accountsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: accounts.json: %v", ErrInvalidAccount, err)
+	}
+
+	seen := make(map[string]bool)
+	accounts := make([]Account, 0, len(file.Accounts))
+	for i, entry := range file.Accounts {
+		if entry.Name == "" {
+			return nil, fmt.Errorf("%w: account %d has no name", ErrInvalidAccount, i+1)
+		}
+		if seen[entry.Name] {
+			return nil, fmt.Errorf("%w: duplicate account %s", ErrInvalidAccount, entry.Name)
+		}
+		seen[entry.Name] = true
+
+		if len(entry.Owners) == 0 {
+			return nil, fmt.Errorf("%w: owners is empty for %s", ErrInvalidAccount, entry.Name)
+		}
+		for _, pattern := range entry.Owners {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%w: bad owner pattern %q for %s", ErrInvalidAccount, pattern, entry.Name)
+			}
+		}
+
+		account := Account{Name: entry.Name, Owners: entry.Owners}
+		switch {
+		case entry.App != nil:
+			key, err := os.ReadFile(entry.App.PrivateKeyPath)
+			if err != nil {
+				return nil, fmt.Errorf("%w: failed to read private key for %s: %v", ErrInvalidAccount, entry.Name, err)
+			}
+			account.App = &GitHubApp{
+				AppID:          entry.App.AppID,
+				PrivateKey:     key,
+				InstallationID: entry.App.InstallationID,
+			}
+		case entry.TokenEnv != "":
+			account.Token = os.Getenv(entry.TokenEnv)
+			if account.Token == "" {
+				return nil, fmt.Errorf("%w: %s is not set for %s", ErrInvalidAccount, entry.TokenEnv, entry.Name)
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s needs token_env or app", ErrInvalidAccount, entry.Name)
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	logger.Info("accounts.json loaded", "path", configPath, "accounts", len(accounts))
+	return accounts, nil
+}
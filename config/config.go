@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
@@ -17,11 +19,32 @@ var (
 	ErrInvalidJSON       = errors.New("invalid JSON in repos.json")
 	ErrMissingDefault    = errors.New("missing default branch configuration")
 	ErrInvalidBranchName = errors.New("invalid branch name")
+	ErrInvalidRollback   = errors.New("invalid rollback configuration")
+	ErrInvalidGitHubApp  = errors.New("invalid GitHub App configuration")
+	ErrInvalidProvider   = errors.New("invalid provider")
+)
+
+// Providers a repository can be hosted on.
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+	ProviderGitea  = "gitea"
+	ProviderLocal  = "local"
 )
 
 type BranchConfig struct {
-	ProdBranch string `json:"prod_branch"`
-	DevBranch  string `json:"dev_branch"`
+	ProdBranch string          `json:"prod_branch"`
+	DevBranch  string          `json:"dev_branch"`
+	Provider   string          `json:"provider,omitempty"` // github (default), gitlab, gitea or local
+	Rollback   *RollbackConfig `json:"rollback,omitempty"`
+}
+
+// RollbackConfig describes the workflow dispatched by the workflow rollback
+// strategy when no workflow_id is given.
+type RollbackConfig struct {
+	Workflow string            `json:"workflow"`         // Workflow file name, e.g. rollback.yml
+	Ref      string            `json:"ref,omitempty"`    // Defaults to the repository's default branch
+	Inputs   map[string]string `json:"inputs,omitempty"` // Static workflow_dispatch inputs
 }
 
 type ReposConfig struct {
@@ -31,13 +54,77 @@ type ReposConfig struct {
 
 type Config struct {
 	GitHubToken string
+	GitHubApp   *GitHubApp // Used instead of GitHubToken when set
+	Accounts    []Account  // Per-owner credentials from accounts.json, matched before the above
+	GitHub      GitHubEndpoints
+	GitLab      GitLabConfig
+	Gitea       GiteaConfig
+	LocalGit    LocalGitConfig
 	ReposConfig ReposConfig
 }
 
+// GitLabConfig locates the GitLab instance serving repositories whose
+// provider is gitlab.
+type GitLabConfig struct {
+	URL   string // GITLAB_URL, defaults to https://gitlab.com
+	Token string // GITLAB_TOKEN
+}
+
+// GiteaConfig locates the Gitea or Forgejo instance serving repositories
+// whose provider is gitea.
+type GiteaConfig struct {
+	URL   string // GITEA_URL, e.g. https://git.example.com
+	Token string // GITEA_TOKEN
+}
+
+// LocalGitConfig locates the on-disk mirrors serving repositories whose
+// provider is local.
+type LocalGitConfig struct {
+	Root string // LOCAL_GIT_ROOT, holding <owner>/<repo>[.git] repositories
+}
+
+// GitHubEndpoints locates the GitHub instance. Empty fields default to
+// github.com; for GitHub Enterprise Server setting WebURL is enough.
+type GitHubEndpoints struct {
+	APIURL    string // GITHUB_API_URL
+	UploadURL string // GITHUB_UPLOAD_URL
+	WebURL    string // GITHUB_WEB_URL
+}
+
+// GitHubApp holds the credentials of a GitHub App, which authenticates with
+// short-lived installation tokens instead of a personal access token.
+type GitHubApp struct {
+	AppID          int64  // GITHUB_APP_ID
+	PrivateKey     []byte // GITHUB_APP_PRIVATE_KEY, or the file at GITHUB_APP_PRIVATE_KEY_PATH
+	InstallationID int64  // GITHUB_APP_INSTALLATION_ID, for requests not tied to an owner
+}
+
 func Load(logger *logging.Logger) (*Config, error) {
 	loadEnvFiles(logger)
 
 	token := os.Getenv("GITHUB_TOKEN")
+	endpoints := GitHubEndpoints{
+		APIURL:    os.Getenv("GITHUB_API_URL"),
+		UploadURL: os.Getenv("GITHUB_UPLOAD_URL"),
+		WebURL:    os.Getenv("GITHUB_WEB_URL"),
+	}
+	gitLab := GitLabConfig{
+		URL:   os.Getenv("GITLAB_URL"),
+		Token: os.Getenv("GITLAB_TOKEN"),
+	}
+	gitea := GiteaConfig{
+		URL:   os.Getenv("GITEA_URL"),
+		Token: os.Getenv("GITEA_TOKEN"),
+	}
+	localGit := LocalGitConfig{Root: os.Getenv("LOCAL_GIT_ROOT")}
+	app, err := loadGitHubApp()
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := loadAccounts(logger)
+	if err != nil {
+		return nil, err
+	}
 	reposConfig, err := loadReposConfig(logger)
 	if err != nil {
 		return nil, err
@@ -45,15 +132,63 @@ func Load(logger *logging.Logger) (*Config, error) {
 
 	logger.Info("config loaded successfully",
 		"has_token", token != "",
+		"github_app", app != nil,
+		"accounts", len(accounts),
+		"enterprise", endpoints != GitHubEndpoints{},
 		"repos_configured", len(reposConfig.Repositories),
 	)
 
 	return &Config{
 		GitHubToken: token,
+		GitHubApp:   app,
+		Accounts:    accounts,
+		GitHub:      endpoints,
+		GitLab:      gitLab,
+		Gitea:       gitea,
+		LocalGit:    localGit,
 		ReposConfig: reposConfig,
 	}, nil
 }
 
+// loadGitHubApp reads GitHub App credentials from the environment. It
+// returns nil when GITHUB_APP_ID is not set.
+func loadGitHubApp() (*GitHubApp, error) {
+	rawID := os.Getenv("GITHUB_APP_ID")
+	if rawID == "" {
+		return nil, nil
+	}
+
+	appID, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: GITHUB_APP_ID %q is not a number", ErrInvalidGitHubApp, rawID)
+	}
+
+	var installationID int64
+	if raw := os.Getenv("GITHUB_APP_INSTALLATION_ID"); raw != "" {
+		installationID, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: GITHUB_APP_INSTALLATION_ID %q is not a number", ErrInvalidGitHubApp, raw)
+		}
+	}
+
+	key := []byte(os.Getenv("GITHUB_APP_PRIVATE_KEY"))
+	if path := os.Getenv("GITHUB_APP_PRIVATE_KEY_PATH"); len(key) == 0 && path != "" {
+		key, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read private key: %v", ErrInvalidGitHubApp, err)
+		}
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("%w: GITHUB_APP_PRIVATE_KEY or GITHUB_APP_PRIVATE_KEY_PATH is required", ErrInvalidGitHubApp)
+	}
+
+	return &GitHubApp{
+		AppID:          appID,
+		PrivateKey:     key,
+		InstallationID: installationID,
+	}, nil
+}
+
 func loadEnvFiles(logger *logging.Logger) {
 	if err := godotenv.Load(); err == nil {
 		logger.Debug("loaded .env from current directory")
@@ -143,6 +278,21 @@ func validateBranchConfig(name string, bc BranchConfig) error {
 		return fmt.Errorf("%w: dev_branch contains whitespace for %s", ErrInvalidBranchName, name)
 	}
 
+	switch bc.Provider {
+	case "", ProviderGitHub, ProviderGitLab, ProviderGitea, ProviderLocal:
+	default:
+		return fmt.Errorf("%w: %q for %s (expected github, gitlab, gitea or local)", ErrInvalidProvider, bc.Provider, name)
+	}
+
+	if bc.Rollback != nil {
+		if bc.Rollback.Workflow == "" {
+			return fmt.Errorf("%w: rollback.workflow is empty for %s", ErrInvalidRollback, name)
+		}
+		if strings.ContainsAny(bc.Rollback.Ref, " \t\n") {
+			return fmt.Errorf("%w: rollback.ref contains whitespace for %s", ErrInvalidRollback, name)
+		}
+	}
+
 	return nil
 }
 
@@ -152,3 +302,20 @@ func (c *Config) GetBranchConfig(repoFullName string) BranchConfig {
 	}
 	return c.ReposConfig.Default
 }
+
+// RepositoriesOn returns the configured repositories hosted on provider.
+// Repositories without a provider are on GitHub.
+func (c *Config) RepositoriesOn(provider string) []string {
+	var repos []string
+	for name, bc := range c.ReposConfig.Repositories {
+		p := bc.Provider
+		if p == "" {
+			p = ProviderGitHub
+		}
+		if p == provider {
+			repos = append(repos, name)
+		}
+	}
+	sort.Strings(repos)
+	return repos
+}
@@ -0,0 +1,37 @@
+package port
+
+import (
+	"errors"
+
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+var ErrUnknownCacheNamespace = errors.New("unknown cache namespace")
+
+// CacheInvalidator drops cached reads that a write has made stale. Use cases
+// call it after a successful write without knowing how data is cached.
+type CacheInvalidator interface {
+	// InvalidateRepository drops everything cached about repository
+	// (owner/repo), including listings that span several repositories.
+	InvalidateRepository(repository string)
+}
+
+// CacheManager inspects and clears the API cache.
+type CacheManager interface {
+	CacheStats() entity.CacheStats
+
+	// ClearRepository drops everything cached about repository, like
+	// InvalidateRepository, and returns how many entries were removed.
+	ClearRepository(repository string) int
+
+	// ClearNamespace drops every entry of a namespace, or the whole cache
+	// when namespace is empty, and returns how many entries were removed.
+	// Unknown namespaces fail with ErrUnknownCacheNamespace.
+	ClearNamespace(namespace string) (int, error)
+}
+
+// APIStatusProvider reports the state of the API client.
+type APIStatusProvider interface {
+	RateLimitStatus() entity.RateLimitStatus
+	RetryStats() entity.RetryStats
+}
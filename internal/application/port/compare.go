@@ -0,0 +1,20 @@
+package port
+
+import "context"
+
+type comparisonFirstPageKey struct{}
+
+// WithComparisonFirstPage returns a context that asks CompareBranches for the
+// first page of commits only. Callers that need the ahead and behind counts
+// and the changed files, such as drift checks, save the requests for later
+// pages. A comparison with more commits than the first page holds is marked
+// CommitsTruncated. Implementations that compare in a single request ignore it.
+func WithComparisonFirstPage(ctx context.Context) context.Context {
+	return context.WithValue(ctx, comparisonFirstPageKey{}, true)
+}
+
+// ComparisonFirstPage reports whether WithComparisonFirstPage was set.
+func ComparisonFirstPage(ctx context.Context) bool {
+	firstPage, _ := ctx.Value(comparisonFirstPageKey{}).(bool)
+	return firstPage
+}
@@ -0,0 +1,76 @@
+package port
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Typed errors wrapped by GitHubClient implementations, so callers can tell
+// failure causes apart without depending on the API client's error types.
+var (
+	ErrUnauthorized = errors.New("not authorized")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limit exceeded")
+	ErrUnsupported  = errors.New("not supported")
+)
+
+// UnsupportedError is returned by implementations for an operation the
+// provider hosting the repository has no equivalent of. It wraps
+// ErrUnsupported; unlike other failures, retrying cannot help.
+type UnsupportedError struct {
+	Provider  string // e.g. gitea
+	Operation string // What is missing, e.g. "re-running workflow runs"
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s does not support %s", e.Provider, e.Operation)
+}
+
+func (e *UnsupportedError) Unwrap() error {
+	return ErrUnsupported
+}
+
+// RetryLaterError is returned when the API asks for a longer wait than the
+// client accepts. It wraps ErrRateLimited, and RetryAt tells the caller when
+// the request can succeed again.
+type RetryLaterError struct {
+	Resource string // Rate limit bucket, e.g. core, search or graphql
+	RetryAt  time.Time
+	Err      error
+}
+
+func (e *RetryLaterError) Error() string {
+	return fmt.Sprintf("%s rate limit exceeded, retry after %s", e.Resource, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *RetryLaterError) Unwrap() error {
+	return e.Err
+}
+
+// PartialError is returned together with the results of a listing merged
+// from several accounts when some of them failed. The results cover the
+// accounts that answered; Errs holds one error per account that did not.
+type PartialError struct {
+	Errs []error
+}
+
+func (e *PartialError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("results incomplete, %d account(s) failed: %s", len(e.Errs), strings.Join(msgs, "; "))
+}
+
+func (e *PartialError) Unwrap() []error {
+	return e.Errs
+}
+
+// IsPartial reports whether err is a *PartialError, meaning the results
+// returned with it are usable but incomplete.
+func IsPartial(err error) bool {
+	var partial *PartialError
+	return errors.As(err, &partial)
+}
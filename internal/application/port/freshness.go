@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+type maxAgeKey struct{}
+
+// WithMaxAge returns a context that asks GitHubClient implementations for
+// data no older than maxAge. Zero asks for data straight from the API.
+func WithMaxAge(ctx context.Context, maxAge time.Duration) context.Context {
+	return context.WithValue(ctx, maxAgeKey{}, maxAge)
+}
+
+// MaxAge returns the limit set by WithMaxAge, if any.
+func MaxAge(ctx context.Context) (time.Duration, bool) {
+	maxAge, ok := ctx.Value(maxAgeKey{}).(time.Duration)
+	return maxAge, ok
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
 )
 
+// GitHubClient is implemented by every provider client. Listings merged from
+// several accounts can return results together with a *PartialError, so
+// callers check IsPartial before discarding them.
 type GitHubClient interface {
 	ListRepositories(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error)
 	GetRepository(ctx context.Context, owner, repo string) (*entity.Repository, error)
@@ -16,14 +19,27 @@ type GitHubClient interface {
 	MergePullRequest(ctx context.Context, owner, repo string, number int, method, commitTitle string) (*entity.MergeResult, error)
 
 	ListCommits(ctx context.Context, filter entity.CommitFilter) ([]entity.Commit, error)
+	GetCommit(ctx context.Context, owner, repo, sha string) (*entity.Commit, error)
 
 	ListWorkflowRuns(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error)
+	GetWorkflowRun(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error)
+	GetWorkflow(ctx context.Context, owner, repo, workflowID string) (*entity.Workflow, error)
 	RerunWorkflow(ctx context.Context, owner, repo string, runID int64) error
-	TriggerWorkflow(ctx context.Context, owner, repo, workflowID, ref string) error
+	TriggerWorkflow(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error
 
 	CompareBranches(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error)
 
+	CreateBranch(ctx context.Context, owner, repo, branch, fromBranch string) error
 	DeleteBranch(ctx context.Context, owner, repo, branch string) error
 
+	// CheckRevert returns an *UnsupportedError when RevertCommit cannot work
+	// for the repository, so a rollback can be refused before it creates a
+	// branch. It makes no API calls.
+	CheckRevert(ctx context.Context, owner, repo string) error
+
+	// RevertCommit creates a commit on top of branch that undoes the changes
+	// introduced by sha, and advances branch to it.
+	RevertCommit(ctx context.Context, owner, repo, branch, sha string) (*entity.Commit, error)
+
 	GetCurrentUser(ctx context.Context) (string, error)
 }
@@ -0,0 +1,76 @@
+package port
+
+import (
+	"sync"
+
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+// MockCacheInvalidator is a mock implementation of CacheInvalidator for testing.
+type MockCacheInvalidator struct {
+	mu                      sync.Mutex
+	InvalidatedRepositories []string
+}
+
+func NewMockCacheInvalidator() *MockCacheInvalidator {
+	return &MockCacheInvalidator{
+		InvalidatedRepositories: []string{},
+	}
+}
+
+func (m *MockCacheInvalidator) InvalidateRepository(repository string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.InvalidatedRepositories = append(m.InvalidatedRepositories, repository)
+}
+
+// MockCacheManager is a mock implementation of CacheManager for testing.
+type MockCacheManager struct {
+	Stats               entity.CacheStats
+	ClearRepositoryFunc func(repository string) int
+	ClearNamespaceFunc  func(namespace string) (int, error)
+
+	ClearedRepositories []string
+	ClearedNamespaces   []string
+}
+
+func NewMockCacheManager() *MockCacheManager {
+	return &MockCacheManager{
+		ClearedRepositories: []string{},
+		ClearedNamespaces:   []string{},
+	}
+}
+
+func (m *MockCacheManager) CacheStats() entity.CacheStats {
+	return m.Stats
+}
+
+func (m *MockCacheManager) ClearRepository(repository string) int {
+	m.ClearedRepositories = append(m.ClearedRepositories, repository)
+	if m.ClearRepositoryFunc != nil {
+		return m.ClearRepositoryFunc(repository)
+	}
+	return 0
+}
+
+func (m *MockCacheManager) ClearNamespace(namespace string) (int, error) {
+	m.ClearedNamespaces = append(m.ClearedNamespaces, namespace)
+	if m.ClearNamespaceFunc != nil {
+		return m.ClearNamespaceFunc(namespace)
+	}
+	return 0, nil
+}
+
+// MockAPIStatusProvider is a mock implementation of APIStatusProvider for testing.
+type MockAPIStatusProvider struct {
+	RateLimit entity.RateLimitStatus
+	Retries   entity.RetryStats
+}
+
+func (m *MockAPIStatusProvider) RateLimitStatus() entity.RateLimitStatus {
+	return m.RateLimit
+}
+
+func (m *MockAPIStatusProvider) RetryStats() entity.RetryStats {
+	return m.Retries
+}
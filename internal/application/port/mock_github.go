@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"sync"
 
 	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
 )
@@ -16,19 +17,28 @@ type MockGitHubClient struct {
 	CreatePullRequestFunc  func(ctx context.Context, owner, repo, title, body, head, base string, draft bool) (*entity.PullRequest, error)
 	MergePullRequestFunc   func(ctx context.Context, owner, repo string, number int, method, commitTitle string) (*entity.MergeResult, error)
 	ListCommitsFunc        func(ctx context.Context, filter entity.CommitFilter) ([]entity.Commit, error)
+	GetCommitFunc          func(ctx context.Context, owner, repo, sha string) (*entity.Commit, error)
 	ListWorkflowRunsFunc   func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error)
+	GetWorkflowRunFunc     func(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error)
+	GetWorkflowFunc        func(ctx context.Context, owner, repo, workflowID string) (*entity.Workflow, error)
 	RerunWorkflowFunc      func(ctx context.Context, owner, repo string, runID int64) error
-	TriggerWorkflowFunc    func(ctx context.Context, owner, repo, workflowID, ref string) error
+	TriggerWorkflowFunc    func(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error
 	CompareBranchesFunc    func(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error)
+	CreateBranchFunc       func(ctx context.Context, owner, repo, branch, fromBranch string) error
 	DeleteBranchFunc       func(ctx context.Context, owner, repo, branch string) error
+	CheckRevertFunc        func(ctx context.Context, owner, repo string) error
+	RevertCommitFunc       func(ctx context.Context, owner, repo, branch, sha string) (*entity.Commit, error)
 	GetCurrentUserFunc     func(ctx context.Context) (string, error)
 
-	// Call tracking
+	// Call tracking, guarded by mu so use cases may call the mock concurrently
+	mu                     sync.Mutex
 	CompareBranchesCalls   []CompareBranchesCall
 	CreatePRCalls          []CreatePRCall
 	MergePullRequestCalls  []MergePRCall
 	DeleteBranchCalls      []DeleteBranchCall
 	GetPullRequestCalls    []GetPRCall
+	CreateBranchCalls      []CreateBranchCall
+	RevertCommitCalls      []RevertCommitCall
 }
 
 type CompareBranchesCall struct {
@@ -55,6 +65,14 @@ type GetPRCall struct {
 	Number      int
 }
 
+type CreateBranchCall struct {
+	Owner, Repo, Branch, FromBranch string
+}
+
+type RevertCommitCall struct {
+	Owner, Repo, Branch, SHA string
+}
+
 func NewMockGitHubClient() *MockGitHubClient {
 	return &MockGitHubClient{
 		CompareBranchesCalls:  []CompareBranchesCall{},
@@ -62,6 +80,8 @@ func NewMockGitHubClient() *MockGitHubClient {
 		MergePullRequestCalls: []MergePRCall{},
 		DeleteBranchCalls:     []DeleteBranchCall{},
 		GetPullRequestCalls:   []GetPRCall{},
+		CreateBranchCalls:     []CreateBranchCall{},
+		RevertCommitCalls:     []RevertCommitCall{},
 	}
 }
 
@@ -87,11 +107,13 @@ func (m *MockGitHubClient) ListPullRequests(ctx context.Context, filter entity.P
 }
 
 func (m *MockGitHubClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*entity.PullRequest, error) {
+	m.mu.Lock()
 	m.GetPullRequestCalls = append(m.GetPullRequestCalls, GetPRCall{
 		Owner:  owner,
 		Repo:   repo,
 		Number: number,
 	})
+	m.mu.Unlock()
 	if m.GetPullRequestFunc != nil {
 		return m.GetPullRequestFunc(ctx, owner, repo, number)
 	}
@@ -99,6 +121,7 @@ func (m *MockGitHubClient) GetPullRequest(ctx context.Context, owner, repo strin
 }
 
 func (m *MockGitHubClient) CreatePullRequest(ctx context.Context, owner, repo, title, body, head, base string, draft bool) (*entity.PullRequest, error) {
+	m.mu.Lock()
 	m.CreatePRCalls = append(m.CreatePRCalls, CreatePRCall{
 		Owner: owner,
 		Repo:  repo,
@@ -108,6 +131,7 @@ func (m *MockGitHubClient) CreatePullRequest(ctx context.Context, owner, repo, t
 		Base:  base,
 		Draft: draft,
 	})
+	m.mu.Unlock()
 	if m.CreatePullRequestFunc != nil {
 		return m.CreatePullRequestFunc(ctx, owner, repo, title, body, head, base, draft)
 	}
@@ -115,6 +139,7 @@ func (m *MockGitHubClient) CreatePullRequest(ctx context.Context, owner, repo, t
 }
 
 func (m *MockGitHubClient) MergePullRequest(ctx context.Context, owner, repo string, number int, method, commitTitle string) (*entity.MergeResult, error) {
+	m.mu.Lock()
 	m.MergePullRequestCalls = append(m.MergePullRequestCalls, MergePRCall{
 		Owner:       owner,
 		Repo:        repo,
@@ -122,6 +147,7 @@ func (m *MockGitHubClient) MergePullRequest(ctx context.Context, owner, repo str
 		Method:      method,
 		CommitTitle: commitTitle,
 	})
+	m.mu.Unlock()
 	if m.MergePullRequestFunc != nil {
 		return m.MergePullRequestFunc(ctx, owner, repo, number, method, commitTitle)
 	}
@@ -135,6 +161,13 @@ func (m *MockGitHubClient) ListCommits(ctx context.Context, filter entity.Commit
 	return []entity.Commit{}, nil
 }
 
+func (m *MockGitHubClient) GetCommit(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+	if m.GetCommitFunc != nil {
+		return m.GetCommitFunc(ctx, owner, repo, sha)
+	}
+	return &entity.Commit{SHA: sha}, nil
+}
+
 func (m *MockGitHubClient) ListWorkflowRuns(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
 	if m.ListWorkflowRunsFunc != nil {
 		return m.ListWorkflowRunsFunc(ctx, filter)
@@ -142,6 +175,20 @@ func (m *MockGitHubClient) ListWorkflowRuns(ctx context.Context, filter entity.C
 	return []entity.WorkflowRun{}, nil
 }
 
+func (m *MockGitHubClient) GetWorkflowRun(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+	if m.GetWorkflowRunFunc != nil {
+		return m.GetWorkflowRunFunc(ctx, owner, repo, runID)
+	}
+	return &entity.WorkflowRun{ID: runID, Status: "completed", Conclusion: "failure"}, nil
+}
+
+func (m *MockGitHubClient) GetWorkflow(ctx context.Context, owner, repo, workflowID string) (*entity.Workflow, error) {
+	if m.GetWorkflowFunc != nil {
+		return m.GetWorkflowFunc(ctx, owner, repo, workflowID)
+	}
+	return &entity.Workflow{Name: "rollback", Path: ".github/workflows/rollback.yml", State: "active"}, nil
+}
+
 func (m *MockGitHubClient) RerunWorkflow(ctx context.Context, owner, repo string, runID int64) error {
 	if m.RerunWorkflowFunc != nil {
 		return m.RerunWorkflowFunc(ctx, owner, repo, runID)
@@ -149,38 +196,79 @@ func (m *MockGitHubClient) RerunWorkflow(ctx context.Context, owner, repo string
 	return nil
 }
 
-func (m *MockGitHubClient) TriggerWorkflow(ctx context.Context, owner, repo, workflowID, ref string) error {
+func (m *MockGitHubClient) TriggerWorkflow(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error {
 	if m.TriggerWorkflowFunc != nil {
-		return m.TriggerWorkflowFunc(ctx, owner, repo, workflowID, ref)
+		return m.TriggerWorkflowFunc(ctx, owner, repo, workflowID, ref, inputs)
 	}
 	return nil
 }
 
 func (m *MockGitHubClient) CompareBranches(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+	m.mu.Lock()
 	m.CompareBranchesCalls = append(m.CompareBranchesCalls, CompareBranchesCall{
 		Owner: owner,
 		Repo:  repo,
 		Base:  base,
 		Head:  head,
 	})
+	m.mu.Unlock()
 	if m.CompareBranchesFunc != nil {
 		return m.CompareBranchesFunc(ctx, owner, repo, base, head)
 	}
 	return &entity.BranchComparison{}, nil
 }
 
+func (m *MockGitHubClient) CreateBranch(ctx context.Context, owner, repo, branch, fromBranch string) error {
+	m.mu.Lock()
+	m.CreateBranchCalls = append(m.CreateBranchCalls, CreateBranchCall{
+		Owner:      owner,
+		Repo:       repo,
+		Branch:     branch,
+		FromBranch: fromBranch,
+	})
+	m.mu.Unlock()
+	if m.CreateBranchFunc != nil {
+		return m.CreateBranchFunc(ctx, owner, repo, branch, fromBranch)
+	}
+	return nil
+}
+
 func (m *MockGitHubClient) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	m.mu.Lock()
 	m.DeleteBranchCalls = append(m.DeleteBranchCalls, DeleteBranchCall{
 		Owner:  owner,
 		Repo:   repo,
 		Branch: branch,
 	})
+	m.mu.Unlock()
 	if m.DeleteBranchFunc != nil {
 		return m.DeleteBranchFunc(ctx, owner, repo, branch)
 	}
 	return nil
 }
 
+func (m *MockGitHubClient) CheckRevert(ctx context.Context, owner, repo string) error {
+	if m.CheckRevertFunc != nil {
+		return m.CheckRevertFunc(ctx, owner, repo)
+	}
+	return nil
+}
+
+func (m *MockGitHubClient) RevertCommit(ctx context.Context, owner, repo, branch, sha string) (*entity.Commit, error) {
+	m.mu.Lock()
+	m.RevertCommitCalls = append(m.RevertCommitCalls, RevertCommitCall{
+		Owner:  owner,
+		Repo:   repo,
+		Branch: branch,
+		SHA:    sha,
+	})
+	m.mu.Unlock()
+	if m.RevertCommitFunc != nil {
+		return m.RevertCommitFunc(ctx, owner, repo, branch, sha)
+	}
+	return &entity.Commit{SHA: "revert123", Parents: []string{"head123"}}, nil
+}
+
 func (m *MockGitHubClient) GetCurrentUser(ctx context.Context) (string, error) {
 	if m.GetCurrentUserFunc != nil {
 		return m.GetCurrentUserFunc(ctx)
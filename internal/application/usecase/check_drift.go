@@ -33,10 +33,15 @@ type DriftResult struct {
 	Comparison entity.BranchComparison
 	Severity   string
 	Actions    []string
+	Error      *entity.RepoError // Set when the branches could not be compared
 }
 
+// Execute compares one repository, or every listed one. When some accounts
+// could not be listed, the results for the others are returned with the
+// *port.PartialError.
 func (uc *CheckDriftUseCase) Execute(ctx context.Context, input CheckDriftInput) ([]DriftResult, error) {
 	var results []DriftResult
+	var partialErr error
 
 	if input.Repository != "" {
 		result, err := uc.checkSingleRepo(ctx, input.Repository)
@@ -48,13 +53,15 @@ func (uc *CheckDriftUseCase) Execute(ctx context.Context, input CheckDriftInput)
 		}
 	} else {
 		repos, err := uc.client.ListRepositories(ctx, "", false)
-		if err != nil {
+		if err != nil && !port.IsPartial(err) {
 			return nil, err
 		}
+		partialErr = err
 
 		for _, repo := range repos {
 			result, err := uc.checkSingleRepo(ctx, repo.FullName)
 			if err != nil {
+				results = append(results, uc.failedResult(repo.FullName, err))
 				continue
 			}
 			if len(result.Comparison.Files) > 0 {
@@ -63,7 +70,28 @@ func (uc *CheckDriftUseCase) Execute(ctx context.Context, input CheckDriftInput)
 		}
 	}
 
-	return results, nil
+	return results, partialErr
+}
+
+// failedResult records a repository whose branches could not be compared.
+// The repository itself was listed, so a 404 means a configured branch is
+// missing.
+func (uc *CheckDriftUseCase) failedResult(repoFullName string, err error) DriftResult {
+	branchConfig := uc.config.GetBranchConfig(repoFullName)
+
+	repoErr := newRepoError(repoFullName, "compare branches", err)
+	if repoErr.Kind == entity.ErrorNotFound {
+		repoErr.Kind = entity.ErrorMissingBranch
+	}
+
+	return DriftResult{
+		Comparison: entity.BranchComparison{
+			Repository: repoFullName,
+			ProdBranch: branchConfig.ProdBranch,
+			DevBranch:  branchConfig.DevBranch,
+		},
+		Error: &repoErr,
+	}
 }
 
 func (uc *CheckDriftUseCase) checkSingleRepo(ctx context.Context, repoFullName string) (*DriftResult, error) {
@@ -75,7 +103,8 @@ func (uc *CheckDriftUseCase) checkSingleRepo(ctx context.Context, repoFullName s
 
 	branchConfig := uc.config.GetBranchConfig(repoFullName)
 
-	comparison, err := uc.client.CompareBranches(ctx, owner, repo, branchConfig.ProdBranch, branchConfig.DevBranch)
+	// Drift only needs the counts and files, not every commit
+	comparison, err := uc.client.CompareBranches(port.WithComparisonFirstPage(ctx), owner, repo, branchConfig.ProdBranch, branchConfig.DevBranch)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -15,6 +16,9 @@ import (
 func TestCheckDriftUseCase_Execute_SingleRepo(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
 	mockClient.CompareBranchesFunc = func(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+		if !port.ComparisonFirstPage(ctx) {
+			t.Error("CompareBranches fetches every page, want the first page only for drift")
+		}
 		return &entity.BranchComparison{
 			Repository:   owner + "/" + repo,
 			ProdBranch:   base,
@@ -266,3 +270,76 @@ func TestCheckDriftUseCase_Execute_CompareBranchesError(t *testing.T) {
 		t.Error("Execute() error = nil, want error")
 	}
 }
+
+func TestCheckDriftUseCase_Execute_AllRepos_ReportsErrors(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListRepositoriesFunc = func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+		return []entity.Repository{
+			{FullName: "owner/no-develop"},
+			{FullName: "owner/private"},
+			{FullName: "owner/mirror"},
+			{FullName: "owner/drifted"},
+		}, nil
+	}
+	mockClient.CompareBranchesFunc = func(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+		switch repo {
+		case "no-develop":
+			return nil, fmt.Errorf("%w: 404 Not Found", port.ErrNotFound)
+		case "private":
+			return nil, fmt.Errorf("%w: 403 Forbidden", port.ErrUnauthorized)
+		case "mirror":
+			return nil, &port.UnsupportedError{Provider: "forge", Operation: "comparing branches"}
+		}
+		return &entity.BranchComparison{
+			Repository: owner + "/" + repo,
+			AheadBy:    1,
+			Files:      []entity.ChangedFile{{Filename: "main.go"}},
+		}, nil
+	}
+
+	cfg := &config.Config{
+		ReposConfig: config.ReposConfig{
+			Default: config.BranchConfig{
+				ProdBranch: "main",
+				DevBranch:  "develop",
+			},
+			Repositories: make(map[string]config.BranchConfig),
+		},
+	}
+
+	uc := NewCheckDriftUseCase(mockClient, cfg, service.NewDriftDetector())
+
+	results, err := uc.Execute(context.Background(), CheckDriftInput{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("len(results) = %d, want 4", len(results))
+	}
+
+	wantKinds := map[string]entity.ErrorKind{
+		"owner/no-develop": entity.ErrorMissingBranch,
+		"owner/private":    entity.ErrorAuth,
+		"owner/mirror":     entity.ErrorUnsupported,
+	}
+	for _, r := range results {
+		want, failed := wantKinds[r.Comparison.Repository]
+		if !failed {
+			if r.Error != nil {
+				t.Errorf("%s: Error = %v, want nil", r.Comparison.Repository, r.Error)
+			}
+			continue
+		}
+		if r.Error == nil {
+			t.Errorf("%s: Error = nil, want %s", r.Comparison.Repository, want)
+			continue
+		}
+		if r.Error.Kind != want {
+			t.Errorf("%s: Kind = %s, want %s", r.Comparison.Repository, r.Error.Kind, want)
+		}
+		if r.Comparison.DevBranch != "develop" {
+			t.Errorf("%s: DevBranch = %s, want develop", r.Comparison.Repository, r.Comparison.DevBranch)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+)
+
+type ClearCacheUseCase struct {
+	cache port.CacheManager
+}
+
+func NewClearCacheUseCase(cache port.CacheManager) *ClearCacheUseCase {
+	return &ClearCacheUseCase{cache: cache}
+}
+
+// ClearCacheInput selects what to clear: a repository, a namespace, or the
+// whole cache when both are empty.
+type ClearCacheInput struct {
+	Repository string
+	Namespace  string
+}
+
+type ClearCacheResult struct {
+	Scope   string
+	Removed int
+}
+
+func (uc *ClearCacheUseCase) Execute(ctx context.Context, input ClearCacheInput) (*ClearCacheResult, error) {
+	switch {
+	case input.Repository != "" && input.Namespace != "":
+		return nil, fmt.Errorf("specify either a repository or a namespace, not both")
+
+	case input.Repository != "":
+		if len(strings.Split(input.Repository, "/")) != 2 {
+			return nil, fmt.Errorf("invalid repository format, expected owner/repo")
+		}
+		return &ClearCacheResult{
+			Scope:   "repository " + input.Repository,
+			Removed: uc.cache.ClearRepository(input.Repository),
+		}, nil
+
+	default:
+		removed, err := uc.cache.ClearNamespace(input.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		scope := "all entries"
+		if input.Namespace != "" {
+			scope = "namespace " + input.Namespace
+		}
+		return &ClearCacheResult{Scope: scope, Removed: removed}, nil
+	}
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+)
+
+func TestClearCacheUseCase_Execute_Repository(t *testing.T) {
+	cacheManager := port.NewMockCacheManager()
+	cacheManager.ClearRepositoryFunc = func(repository string) int { return 3 }
+	uc := NewClearCacheUseCase(cacheManager)
+
+	result, err := uc.Execute(context.Background(), ClearCacheInput{Repository: "test/repo"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if result.Removed != 3 {
+		t.Errorf("Removed = %d, want 3", result.Removed)
+	}
+	if len(cacheManager.ClearedRepositories) != 1 || cacheManager.ClearedRepositories[0] != "test/repo" {
+		t.Errorf("ClearedRepositories = %v, want [test/repo]", cacheManager.ClearedRepositories)
+	}
+	if len(cacheManager.ClearedNamespaces) != 0 {
+		t.Errorf("ClearedNamespaces = %v, want none", cacheManager.ClearedNamespaces)
+	}
+}
+
+func TestClearCacheUseCase_Execute_Namespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		wantScope string
+	}{
+		{"namespace", "prs", "namespace prs"},
+		{"everything", "", "all entries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheManager := port.NewMockCacheManager()
+			uc := NewClearCacheUseCase(cacheManager)
+
+			result, err := uc.Execute(context.Background(), ClearCacheInput{Namespace: tt.namespace})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if result.Scope != tt.wantScope {
+				t.Errorf("Scope = %s, want %s", result.Scope, tt.wantScope)
+			}
+			if len(cacheManager.ClearedNamespaces) != 1 || cacheManager.ClearedNamespaces[0] != tt.namespace {
+				t.Errorf("ClearedNamespaces = %v, want [%s]", cacheManager.ClearedNamespaces, tt.namespace)
+			}
+		})
+	}
+}
+
+func TestClearCacheUseCase_Execute_UnknownNamespace(t *testing.T) {
+	cacheManager := port.NewMockCacheManager()
+	cacheManager.ClearNamespaceFunc = func(namespace string) (int, error) {
+		return 0, port.ErrUnknownCacheNamespace
+	}
+	uc := NewClearCacheUseCase(cacheManager)
+
+	_, err := uc.Execute(context.Background(), ClearCacheInput{Namespace: "commits"})
+	if !errors.Is(err, port.ErrUnknownCacheNamespace) {
+		t.Errorf("Execute() error = %v, want ErrUnknownCacheNamespace", err)
+	}
+}
+
+func TestClearCacheUseCase_Execute_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input ClearCacheInput
+	}{
+		{"both scopes", ClearCacheInput{Repository: "test/repo", Namespace: "prs"}},
+		{"invalid repository", ClearCacheInput{Repository: "invalid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheManager := port.NewMockCacheManager()
+			uc := NewClearCacheUseCase(cacheManager)
+
+			if _, err := uc.Execute(context.Background(), tt.input); err == nil {
+				t.Fatal("Execute() error = nil, want error")
+			}
+			if len(cacheManager.ClearedRepositories)+len(cacheManager.ClearedNamespaces) != 0 {
+				t.Error("cache cleared on invalid input")
+			}
+		})
+	}
+}
@@ -10,11 +10,12 @@ import (
 )
 
 type CreatePRUseCase struct {
-	client port.GitHubClient
+	client      port.GitHubClient
+	invalidator port.CacheInvalidator
 }
 
-func NewCreatePRUseCase(client port.GitHubClient) *CreatePRUseCase {
-	return &CreatePRUseCase{client: client}
+func NewCreatePRUseCase(client port.GitHubClient, invalidator port.CacheInvalidator) *CreatePRUseCase {
+	return &CreatePRUseCase{client: client, invalidator: invalidator}
 }
 
 type CreatePRInput struct {
@@ -75,6 +76,7 @@ func (uc *CreatePRUseCase) Execute(ctx context.Context, input CreatePRInput) (*C
 	if err != nil {
 		return nil, fmt.Errorf("failed to create PR: %w", err)
 	}
+	uc.invalidator.InvalidateRepository(input.Repository)
 
 	return &CreatePRResult{
 		Success: true,
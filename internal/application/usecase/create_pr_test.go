@@ -22,7 +22,7 @@ func TestCreatePRUseCase_Execute_Success(t *testing.T) {
 		}, nil
 	}
 
-	uc := NewCreatePRUseCase(mockClient)
+	uc := NewCreatePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), CreatePRInput{
 		Repository: "test-owner/test-repo",
@@ -70,7 +70,7 @@ func TestCreatePRUseCase_Execute_Draft(t *testing.T) {
 		}, nil
 	}
 
-	uc := NewCreatePRUseCase(mockClient)
+	uc := NewCreatePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), CreatePRInput{
 		Repository: "test/repo",
@@ -103,7 +103,7 @@ func TestCreatePRUseCase_Execute_DryRun(t *testing.T) {
 		}, nil
 	}
 
-	uc := NewCreatePRUseCase(mockClient)
+	uc := NewCreatePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), CreatePRInput{
 		Repository: "test/repo",
@@ -144,7 +144,7 @@ func TestCreatePRUseCase_Execute_DryRunDraft(t *testing.T) {
 		return &entity.BranchComparison{TotalCommits: 1}, nil
 	}
 
-	uc := NewCreatePRUseCase(mockClient)
+	uc := NewCreatePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), CreatePRInput{
 		Repository: "test/repo",
@@ -166,7 +166,7 @@ func TestCreatePRUseCase_Execute_DryRunDraft(t *testing.T) {
 
 func TestCreatePRUseCase_Execute_InvalidRepoFormat(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
-	uc := NewCreatePRUseCase(mockClient)
+	uc := NewCreatePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), CreatePRInput{
 		Repository: "invalid-format",
@@ -182,7 +182,7 @@ func TestCreatePRUseCase_Execute_InvalidRepoFormat(t *testing.T) {
 
 func TestCreatePRUseCase_Execute_MissingTitle(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
-	uc := NewCreatePRUseCase(mockClient)
+	uc := NewCreatePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), CreatePRInput{
 		Repository: "test/repo",
@@ -201,7 +201,7 @@ func TestCreatePRUseCase_Execute_MissingTitle(t *testing.T) {
 
 func TestCreatePRUseCase_Execute_MissingHead(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
-	uc := NewCreatePRUseCase(mockClient)
+	uc := NewCreatePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), CreatePRInput{
 		Repository: "test/repo",
@@ -220,7 +220,7 @@ func TestCreatePRUseCase_Execute_MissingHead(t *testing.T) {
 
 func TestCreatePRUseCase_Execute_MissingBase(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
-	uc := NewCreatePRUseCase(mockClient)
+	uc := NewCreatePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), CreatePRInput{
 		Repository: "test/repo",
@@ -243,7 +243,7 @@ func TestCreatePRUseCase_Execute_APIError(t *testing.T) {
 		return nil, errors.New("422 Validation Failed")
 	}
 
-	uc := NewCreatePRUseCase(mockClient)
+	uc := NewCreatePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), CreatePRInput{
 		Repository: "test/repo",
@@ -260,3 +260,46 @@ func TestCreatePRUseCase_Execute_APIError(t *testing.T) {
 		t.Errorf("error = %v, want to contain 'create PR'", err)
 	}
 }
+
+func TestCreatePRUseCase_Execute_InvalidatesCache(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	invalidator := port.NewMockCacheInvalidator()
+	uc := NewCreatePRUseCase(mockClient, invalidator)
+
+	_, err := uc.Execute(context.Background(), CreatePRInput{
+		Repository: "test/repo",
+		Title:      "feat: add thing",
+		Head:       "feature/thing",
+		Base:       "main",
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(invalidator.InvalidatedRepositories) != 1 || invalidator.InvalidatedRepositories[0] != "test/repo" {
+		t.Errorf("InvalidatedRepositories = %v, want [test/repo]", invalidator.InvalidatedRepositories)
+	}
+}
+
+func TestCreatePRUseCase_Execute_APIErrorKeepsCache(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.CreatePullRequestFunc = func(ctx context.Context, owner, repo, title, body, head, base string, draft bool) (*entity.PullRequest, error) {
+		return nil, errors.New("validation failed")
+	}
+	invalidator := port.NewMockCacheInvalidator()
+	uc := NewCreatePRUseCase(mockClient, invalidator)
+
+	_, err := uc.Execute(context.Background(), CreatePRInput{
+		Repository: "test/repo",
+		Title:      "feat: add thing",
+		Head:       "feature/thing",
+		Base:       "main",
+	})
+	if err == nil {
+		t.Fatal("Execute() error = nil, want error")
+	}
+
+	if len(invalidator.InvalidatedRepositories) != 0 {
+		t.Errorf("InvalidatedRepositories = %v, want none after a failed write", invalidator.InvalidatedRepositories)
+	}
+}
@@ -11,14 +11,16 @@ import (
 )
 
 type CreateSyncPRUseCase struct {
-	client port.GitHubClient
-	config *config.Config
+	client      port.GitHubClient
+	config      *config.Config
+	invalidator port.CacheInvalidator
 }
 
-func NewCreateSyncPRUseCase(client port.GitHubClient, cfg *config.Config) *CreateSyncPRUseCase {
+func NewCreateSyncPRUseCase(client port.GitHubClient, cfg *config.Config, invalidator port.CacheInvalidator) *CreateSyncPRUseCase {
 	return &CreateSyncPRUseCase{
-		client: client,
-		config: cfg,
+		client:      client,
+		config:      cfg,
+		invalidator: invalidator,
 	}
 }
 
@@ -58,11 +60,15 @@ func (uc *CreateSyncPRUseCase) Execute(ctx context.Context, input CreateSyncPRIn
 
 	body := input.Body
 	if body == "" {
-		body = fmt.Sprintf("## Sync PR\n\nThis PR syncs `%s` into `%s`.\n\n### Changes\n- **Commits**: %d\n- **Files changed**: %d\n\n---\n_Created by mcp-repo-monitor_",
+		filesChanged := fmt.Sprintf("%d", len(comparison.Files))
+		if comparison.FilesTruncated {
+			filesChanged += "+ (the comparison was too large for GitHub to list every file)"
+		}
+		body = fmt.Sprintf("## Sync PR\n\nThis PR syncs `%s` into `%s`.\n\n### Changes\n- **Commits**: %d\n- **Files changed**: %s\n\n---\n_Created by mcp-repo-monitor_",
 			branchConfig.ProdBranch,
 			branchConfig.DevBranch,
 			comparison.TotalCommits,
-			len(comparison.Files),
+			filesChanged,
 		)
 	}
 
@@ -79,6 +85,7 @@ func (uc *CreateSyncPRUseCase) Execute(ctx context.Context, input CreateSyncPRIn
 	if err != nil {
 		return nil, fmt.Errorf("failed to create PR: %w", err)
 	}
+	uc.invalidator.InvalidateRepository(input.Repository)
 
 	return &entity.SyncPRResult{
 		Success:      true,
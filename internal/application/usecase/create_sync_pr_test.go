@@ -36,7 +36,7 @@ func TestCreateSyncPRUseCase_Execute_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewCreateSyncPRUseCase(mockClient, cfg)
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), CreateSyncPRInput{
 		Repository: "test-owner/test-repo",
@@ -93,7 +93,7 @@ func TestCreateSyncPRUseCase_Execute_AlreadySynced(t *testing.T) {
 		},
 	}
 
-	uc := NewCreateSyncPRUseCase(mockClient, cfg)
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), CreateSyncPRInput{
 		Repository: "test/repo",
@@ -139,7 +139,7 @@ func TestCreateSyncPRUseCase_Execute_MergeCommitsNoFiles(t *testing.T) {
 		},
 	}
 
-	uc := NewCreateSyncPRUseCase(mockClient, cfg)
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), CreateSyncPRInput{
 		Repository: "test/repo",
@@ -182,7 +182,7 @@ func TestCreateSyncPRUseCase_Execute_DryRun(t *testing.T) {
 		},
 	}
 
-	uc := NewCreateSyncPRUseCase(mockClient, cfg)
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), CreateSyncPRInput{
 		Repository: "test/repo",
@@ -234,7 +234,7 @@ func TestCreateSyncPRUseCase_Execute_CustomTitle(t *testing.T) {
 		},
 	}
 
-	uc := NewCreateSyncPRUseCase(mockClient, cfg)
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), CreateSyncPRInput{
 		Repository: "test/repo",
@@ -267,7 +267,7 @@ func TestCreateSyncPRUseCase_Execute_InvalidRepoFormat(t *testing.T) {
 		},
 	}
 
-	uc := NewCreateSyncPRUseCase(mockClient, cfg)
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), CreateSyncPRInput{
 		Repository: "invalid-format",
@@ -294,7 +294,7 @@ func TestCreateSyncPRUseCase_Execute_CompareBranchesError(t *testing.T) {
 		},
 	}
 
-	uc := NewCreateSyncPRUseCase(mockClient, cfg)
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), CreateSyncPRInput{
 		Repository: "test/repo",
@@ -328,7 +328,7 @@ func TestCreateSyncPRUseCase_Execute_CreatePRError(t *testing.T) {
 		},
 	}
 
-	uc := NewCreateSyncPRUseCase(mockClient, cfg)
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), CreateSyncPRInput{
 		Repository: "test/repo",
@@ -367,7 +367,7 @@ func TestCreateSyncPRUseCase_Execute_CustomBranchConfig(t *testing.T) {
 		},
 	}
 
-	uc := NewCreateSyncPRUseCase(mockClient, cfg)
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), CreateSyncPRInput{
 		Repository: "custom/repo",
@@ -388,3 +388,74 @@ func TestCreateSyncPRUseCase_Execute_CustomBranchConfig(t *testing.T) {
 		t.Errorf("CreatePullRequest called with head=%s base=%s, want head=production base=staging", prCall.Head, prCall.Base)
 	}
 }
+
+func TestCreateSyncPRUseCase_Execute_TruncatedComparison(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.CompareBranchesFunc = func(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+		return &entity.BranchComparison{
+			TotalCommits:   400,
+			Files:          make([]entity.ChangedFile, 300),
+			FilesTruncated: true,
+		}, nil
+	}
+
+	cfg := &config.Config{
+		ReposConfig: config.ReposConfig{
+			Default: config.BranchConfig{
+				ProdBranch: "main",
+				DevBranch:  "develop",
+			},
+			Repositories: make(map[string]config.BranchConfig),
+		},
+	}
+
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
+
+	_, err := uc.Execute(context.Background(), CreateSyncPRInput{
+		Repository: "test-owner/test-repo",
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(mockClient.CreatePRCalls) != 1 {
+		t.Fatalf("CreatePullRequest called %d times, want 1", len(mockClient.CreatePRCalls))
+	}
+
+	body := mockClient.CreatePRCalls[0].Body
+	if !strings.Contains(body, "**Files changed**: 300+") {
+		t.Errorf("Body = %q, want to mark the file count as truncated", body)
+	}
+}
+
+func TestCreateSyncPRUseCase_Execute_CommitsTruncatedKeepsFileCount(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.CompareBranchesFunc = func(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+		return &entity.BranchComparison{
+			TotalCommits:     400,
+			Files:            make([]entity.ChangedFile, 12),
+			CommitsTruncated: true,
+		}, nil
+	}
+
+	cfg := &config.Config{
+		ReposConfig: config.ReposConfig{
+			Default: config.BranchConfig{
+				ProdBranch: "main",
+				DevBranch:  "develop",
+			},
+			Repositories: make(map[string]config.BranchConfig),
+		},
+	}
+
+	uc := NewCreateSyncPRUseCase(mockClient, cfg, port.NewMockCacheInvalidator())
+
+	if _, err := uc.Execute(context.Background(), CreateSyncPRInput{Repository: "test-owner/test-repo"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	body := mockClient.CreatePRCalls[0].Body
+	if !strings.Contains(body, "**Files changed**: 12\n") {
+		t.Errorf("Body = %q, want the complete file count unmarked", body)
+	}
+}
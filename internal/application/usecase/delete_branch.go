@@ -9,11 +9,12 @@ import (
 )
 
 type DeleteBranchUseCase struct {
-	client port.GitHubClient
+	client      port.GitHubClient
+	invalidator port.CacheInvalidator
 }
 
-func NewDeleteBranchUseCase(client port.GitHubClient) *DeleteBranchUseCase {
-	return &DeleteBranchUseCase{client: client}
+func NewDeleteBranchUseCase(client port.GitHubClient, invalidator port.CacheInvalidator) *DeleteBranchUseCase {
+	return &DeleteBranchUseCase{client: client, invalidator: invalidator}
 }
 
 type DeleteBranchInput struct {
@@ -60,6 +61,8 @@ func (uc *DeleteBranchUseCase) Execute(ctx context.Context, input DeleteBranchIn
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete branch '%s': %w", input.Branch, err)
 	}
+	// Deleting a head branch closes its open PRs.
+	uc.invalidator.InvalidateRepository(input.Repository)
 
 	return &DeleteBranchResult{
 		Success: true,
@@ -11,7 +11,7 @@ import (
 
 func TestDeleteBranchUseCase_Execute_Success(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
-	uc := NewDeleteBranchUseCase(mockClient)
+	uc := NewDeleteBranchUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), DeleteBranchInput{
 		Repository: "test/repo",
@@ -42,7 +42,7 @@ func TestDeleteBranchUseCase_Execute_Success(t *testing.T) {
 
 func TestDeleteBranchUseCase_Execute_DryRun(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
-	uc := NewDeleteBranchUseCase(mockClient)
+	uc := NewDeleteBranchUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), DeleteBranchInput{
 		Repository: "test/repo",
@@ -65,7 +65,7 @@ func TestDeleteBranchUseCase_Execute_DryRun(t *testing.T) {
 
 func TestDeleteBranchUseCase_Execute_InvalidRepoFormat(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
-	uc := NewDeleteBranchUseCase(mockClient)
+	uc := NewDeleteBranchUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), DeleteBranchInput{
 		Repository: "invalid",
@@ -79,7 +79,7 @@ func TestDeleteBranchUseCase_Execute_InvalidRepoFormat(t *testing.T) {
 
 func TestDeleteBranchUseCase_Execute_EmptyBranch(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
-	uc := NewDeleteBranchUseCase(mockClient)
+	uc := NewDeleteBranchUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), DeleteBranchInput{
 		Repository: "test/repo",
@@ -101,7 +101,7 @@ func TestDeleteBranchUseCase_Execute_ProtectedBranches(t *testing.T) {
 	for _, branch := range protected {
 		t.Run("protected_"+branch, func(t *testing.T) {
 			mockClient := port.NewMockGitHubClient()
-			uc := NewDeleteBranchUseCase(mockClient)
+			uc := NewDeleteBranchUseCase(mockClient, port.NewMockCacheInvalidator())
 
 			_, err := uc.Execute(context.Background(), DeleteBranchInput{
 				Repository: "test/repo",
@@ -129,7 +129,7 @@ func TestDeleteBranchUseCase_Execute_APIError(t *testing.T) {
 		return errors.New("API error: not found")
 	}
 
-	uc := NewDeleteBranchUseCase(mockClient)
+	uc := NewDeleteBranchUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), DeleteBranchInput{
 		Repository: "test/repo",
@@ -144,3 +144,21 @@ func TestDeleteBranchUseCase_Execute_APIError(t *testing.T) {
 		t.Errorf("error = %v, want to contain 'failed to delete branch'", err)
 	}
 }
+
+func TestDeleteBranchUseCase_Execute_InvalidatesCache(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	invalidator := port.NewMockCacheInvalidator()
+	uc := NewDeleteBranchUseCase(mockClient, invalidator)
+
+	_, err := uc.Execute(context.Background(), DeleteBranchInput{
+		Repository: "test/repo",
+		Branch:     "feature/old-branch",
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(invalidator.InvalidatedRepositories) != 1 || invalidator.InvalidatedRepositories[0] != "test/repo" {
+		t.Errorf("InvalidatedRepositories = %v, want [test/repo]", invalidator.InvalidatedRepositories)
+	}
+}
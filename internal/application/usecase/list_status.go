@@ -2,54 +2,236 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/carlos/mcp-repo-monitor/config"
 	"github.com/carlos/mcp-repo-monitor/internal/application/port"
 	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/service"
+)
+
+// DefaultStatusConcurrency is how many repositories ListStatusUseCase checks
+// at once when no limit is configured.
+const DefaultStatusConcurrency = 8
+
+// statusRunSearchLimit is how many recent runs per branch are searched for
+// the latest completed one.
+const statusRunSearchLimit = 10
+
+// Sort orders for ListStatusInput.SortBy.
+const (
+	StatusSortName   = "name"
+	StatusSortHealth = "health"
 )
 
 type ListStatusUseCase struct {
-	client port.GitHubClient
+	client        port.GitHubClient
+	config        *config.Config
+	driftDetector *service.DriftDetector
+	healthScorer  *service.HealthScorer
+	concurrency   int
+	now           func() time.Time
 }
 
-func NewListStatusUseCase(client port.GitHubClient) *ListStatusUseCase {
-	return &ListStatusUseCase{client: client}
+// NewListStatusUseCase creates the use case. concurrency bounds how many
+// repositories are checked in parallel; values below 1 use the default.
+// Every worker goes through the client, so the client's rate limiter still
+// throttles the pool as a whole.
+func NewListStatusUseCase(
+	client port.GitHubClient,
+	cfg *config.Config,
+	driftDetector *service.DriftDetector,
+	healthScorer *service.HealthScorer,
+	concurrency int,
+) *ListStatusUseCase {
+	if concurrency < 1 {
+		concurrency = DefaultStatusConcurrency
+	}
+	return &ListStatusUseCase{
+		client:        client,
+		config:        cfg,
+		driftDetector: driftDetector,
+		healthScorer:  healthScorer,
+		concurrency:   concurrency,
+		now:           time.Now,
+	}
 }
 
 type ListStatusInput struct {
-	Filter   string
-	Archived bool
+	Filter    string
+	Archived  bool
+	SortBy    string // StatusSortName (default, listing order) or StatusSortHealth (least healthy first)
+	MinHealth int    // Only repositories scoring at least this
+	MaxHealth *int   // Only repositories scoring at most this; nil means no limit
 }
 
+// Execute returns one status per repository, in the order ListRepositories
+// returned them unless input.SortBy says otherwise. Failures for a single
+// repository are recorded on its status; only listing the repositories or a
+// cancelled context fails the call. When some accounts could not be listed,
+// the statuses of the others are returned with the *port.PartialError.
 func (uc *ListStatusUseCase) Execute(ctx context.Context, input ListStatusInput) ([]entity.RepositoryStatus, error) {
-	repos, err := uc.client.ListRepositories(ctx, input.Filter, input.Archived)
-	if err != nil {
+	if input.SortBy != "" && input.SortBy != StatusSortName && input.SortBy != StatusSortHealth {
+		return nil, fmt.Errorf("invalid sort '%s', must be name or health", input.SortBy)
+	}
+
+	repos, listErr := uc.client.ListRepositories(ctx, input.Filter, input.Archived)
+	if listErr != nil && !port.IsPartial(listErr) {
+		return nil, listErr
+	}
+
+	statuses := make([]entity.RepositoryStatus, len(repos))
+	jobs := make(chan int)
+
+	workers := uc.concurrency
+	if workers > len(repos) {
+		workers = len(repos)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				statuses[i] = uc.repoStatus(ctx, repos[i])
+			}
+		}()
+	}
+
+feed:
+	for i := range repos {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	var statuses []entity.RepositoryStatus
-	for _, repo := range repos {
-		status := entity.RepositoryStatus{
-			Repository:   repo,
-			LastCommitAt: repo.PushedAt,
+	return filterAndSortStatuses(statuses, input), listErr
+}
+
+func (uc *ListStatusUseCase) repoStatus(ctx context.Context, repo entity.Repository) entity.RepositoryStatus {
+	status := entity.RepositoryStatus{
+		Repository:   repo,
+		LastCommitAt: repo.PushedAt,
+	}
+
+	prs, err := uc.client.ListPullRequests(ctx, entity.PRFilter{
+		Repository: repo.FullName,
+		State:      "open",
+		Limit:      100,
+	})
+	if err != nil {
+		status.Errors = append(status.Errors, newRepoError(repo.FullName, "list pull requests", err))
+	}
+	status.OpenPRs = len(prs)
+	for _, pr := range prs {
+		if status.OldestPRAt.IsZero() || pr.CreatedAt.Before(status.OldestPRAt) {
+			status.OldestPRAt = pr.CreatedAt
 		}
+	}
 
-		prs, _ := uc.client.ListPullRequests(ctx, entity.PRFilter{
-			Repository: repo.FullName,
-			State:      "open",
-			Limit:      100,
-		})
-		status.OpenPRs = len(prs)
+	branchConfig := uc.config.GetBranchConfig(repo.FullName)
 
-		runs, _ := uc.client.ListWorkflowRuns(ctx, entity.CIFilter{
-			Repository: repo.FullName,
-			Limit:      1,
-		})
-		if len(runs) > 0 && runs[0].Conclusion == "failure" {
+	branches := []string{branchConfig.ProdBranch}
+	if branchConfig.DevBranch != branchConfig.ProdBranch {
+		branches = append(branches, branchConfig.DevBranch)
+	}
+	for _, branch := range branches {
+		ci, err := uc.branchCI(ctx, repo.FullName, branch)
+		if err != nil {
+			status.Errors = append(status.Errors, newRepoError(repo.FullName, "list workflow runs on "+branch, err))
+			continue
+		}
+		status.BranchCI = append(status.BranchCI, ci)
+		if service.IsFailedConclusion(ci.Conclusion) {
 			status.FailedCI = true
 		}
+	}
+
+	if len(branches) > 1 {
+		uc.applyDrift(ctx, &status, branchConfig)
+	}
+
+	status.HealthScore = uc.healthScorer.Score(status, uc.now())
+
+	return status
+}
+
+// branchCI finds the latest completed run on branch.
+func (uc *ListStatusUseCase) branchCI(ctx context.Context, repoFullName, branch string) (entity.BranchCI, error) {
+	ci := entity.BranchCI{Branch: branch}
 
-		statuses = append(statuses, status)
+	runs, err := uc.client.ListWorkflowRuns(ctx, entity.CIFilter{
+		Repository: repoFullName,
+		Branch:     branch,
+		Limit:      statusRunSearchLimit,
+	})
+	if err != nil {
+		return ci, err
+	}
+
+	for _, run := range runs {
+		if run.Status == "completed" {
+			ci.Conclusion = run.Conclusion
+			ci.RunURL = run.HTMLURL
+			break
+		}
+	}
+
+	return ci, nil
+}
+
+// applyDrift compares the configured prod and dev branches.
+func (uc *ListStatusUseCase) applyDrift(ctx context.Context, status *entity.RepositoryStatus, branchConfig config.BranchConfig) {
+	parts := strings.Split(status.Repository.FullName, "/")
+	if len(parts) != 2 {
+		return
+	}
+
+	// Drift only needs the counts and files, not every commit
+	comparison, err := uc.client.CompareBranches(port.WithComparisonFirstPage(ctx), parts[0], parts[1], branchConfig.ProdBranch, branchConfig.DevBranch)
+	if err != nil {
+		repoErr := newRepoError(status.Repository.FullName, "compare branches", err)
+		if repoErr.Kind == entity.ErrorNotFound {
+			repoErr.Kind = entity.ErrorMissingBranch
+		}
+		status.Errors = append(status.Errors, repoErr)
+		return
+	}
+
+	status.HasDrift = uc.driftDetector.AnalyzeDrift(*comparison) != entity.DriftNone
+	status.DriftSeverity = uc.driftDetector.GetDriftSeverity(*comparison)
+}
+
+func filterAndSortStatuses(statuses []entity.RepositoryStatus, input ListStatusInput) []entity.RepositoryStatus {
+	filtered := make([]entity.RepositoryStatus, 0, len(statuses))
+	for _, s := range statuses {
+		if s.HealthScore < input.MinHealth {
+			continue
+		}
+		if input.MaxHealth != nil && s.HealthScore > *input.MaxHealth {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+
+	if input.SortBy == StatusSortHealth {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].HealthScore < filtered[j].HealthScore
+		})
 	}
 
-	return statuses, nil
+	return filtered
 }
@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/service"
+)
+
+func testRepositories(n int) []entity.Repository {
+	repos := make([]entity.Repository, n)
+	for i := range repos {
+		repos[i] = entity.Repository{FullName: fmt.Sprintf("test/repo-%02d", i)}
+	}
+	return repos
+}
+
+func newTestListStatusUseCase(client port.GitHubClient, concurrency int) *ListStatusUseCase {
+	return NewListStatusUseCase(client, newRollbackTestConfig(), service.NewDriftDetector(), service.NewHealthScorer(), concurrency)
+}
+
+func TestListStatusUseCase_Execute_PreservesOrder(t *testing.T) {
+	repos := testRepositories(20)
+
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListRepositoriesFunc = func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+		return repos, nil
+	}
+	mockClient.ListPullRequestsFunc = func(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+		// Finish later repos first so completion order differs from input order.
+		var index int
+		fmt.Sscanf(filter.Repository, "test/repo-%d", &index)
+		time.Sleep(time.Duration(20-index) * time.Millisecond)
+		return make([]entity.PullRequest, index), nil
+	}
+
+	uc := newTestListStatusUseCase(mockClient, 5)
+
+	statuses, err := uc.Execute(context.Background(), ListStatusInput{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(statuses) != len(repos) {
+		t.Fatalf("len(statuses) = %d, want %d", len(statuses), len(repos))
+	}
+	for i, s := range statuses {
+		if s.Repository.FullName != repos[i].FullName {
+			t.Errorf("statuses[%d] = %s, want %s", i, s.Repository.FullName, repos[i].FullName)
+		}
+		if s.OpenPRs != i {
+			t.Errorf("statuses[%d].OpenPRs = %d, want %d", i, s.OpenPRs, i)
+		}
+	}
+}
+
+func TestListStatusUseCase_Execute_BoundsConcurrency(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListRepositoriesFunc = func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+		return testRepositories(30), nil
+	}
+
+	var inFlight, maxInFlight int32
+	mockClient.ListPullRequestsFunc = func(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return nil, nil
+	}
+
+	uc := newTestListStatusUseCase(mockClient, 3)
+
+	if _, err := uc.Execute(context.Background(), ListStatusInput{}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if maxInFlight > 3 {
+		t.Errorf("max concurrent requests = %d, want <= 3", maxInFlight)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("max concurrent requests = %d, want repositories checked in parallel", maxInFlight)
+	}
+}
+
+func TestListStatusUseCase_Execute_CollectsPerRepoErrors(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListRepositoriesFunc = func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+		return testRepositories(3), nil
+	}
+	mockClient.ListPullRequestsFunc = func(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+		if filter.Repository == "test/repo-01" {
+			return nil, fmt.Errorf("%w: 404 Not Found", port.ErrNotFound)
+		}
+		return []entity.PullRequest{{Number: 1}}, nil
+	}
+	mockClient.ListWorkflowRunsFunc = func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+		if filter.Repository == "test/repo-01" {
+			return nil, fmt.Errorf("%w: 403 Forbidden", port.ErrUnauthorized)
+		}
+		return []entity.WorkflowRun{{Status: "completed", Conclusion: "failure"}}, nil
+	}
+
+	uc := newTestListStatusUseCase(mockClient, 2)
+
+	statuses, err := uc.Execute(context.Background(), ListStatusInput{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(statuses[1].Errors) != 3 {
+		t.Fatalf("statuses[1].Errors = %v, want 3 errors", statuses[1].Errors)
+	}
+	if got := statuses[1].Errors[0]; got.Kind != entity.ErrorNotFound || got.Repository != "test/repo-01" {
+		t.Errorf("Errors[0] = %+v, want not_found for test/repo-01", got)
+	}
+	if got := statuses[1].Errors[1].Kind; got != entity.ErrorAuth {
+		t.Errorf("Errors[1].Kind = %s, want %s", got, entity.ErrorAuth)
+	}
+	if len(statuses[0].Errors) != 0 || len(statuses[2].Errors) != 0 {
+		t.Errorf("healthy repos reported errors: %v, %v", statuses[0].Errors, statuses[2].Errors)
+	}
+	if statuses[0].OpenPRs != 1 || !statuses[0].FailedCI {
+		t.Errorf("statuses[0] = %+v, want 1 open PR and failed CI", statuses[0])
+	}
+}
+
+func TestListStatusUseCase_Execute_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListRepositoriesFunc = func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+		return testRepositories(50), nil
+	}
+
+	var mu sync.Mutex
+	checked := 0
+	mockClient.ListPullRequestsFunc = func(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+		mu.Lock()
+		checked++
+		if checked == 2 {
+			cancel()
+		}
+		mu.Unlock()
+		return nil, ctx.Err()
+	}
+
+	uc := newTestListStatusUseCase(mockClient, 2)
+
+	_, err := uc.Execute(ctx, ListStatusInput{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Execute() error = %v, want %v", err, context.Canceled)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if checked >= 50 {
+		t.Errorf("checked %d repositories, want outstanding work to stop after cancel", checked)
+	}
+}
+
+func TestListStatusUseCase_Execute_ChecksConfiguredBranches(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListRepositoriesFunc = func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+		return []entity.Repository{{FullName: "custom/repo", PushedAt: time.Now()}}, nil
+	}
+	mockClient.ListWorkflowRunsFunc = func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+		switch filter.Branch {
+		case "production":
+			return []entity.WorkflowRun{
+				{Status: "in_progress"},
+				{Status: "completed", Conclusion: "failure"},
+			}, nil
+		case "staging":
+			return []entity.WorkflowRun{{Status: "completed", Conclusion: "success"}}, nil
+		}
+		t.Errorf("unexpected branch %q", filter.Branch)
+		return nil, nil
+	}
+	mockClient.CompareBranchesFunc = func(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+		if !port.ComparisonFirstPage(ctx) {
+			t.Error("CompareBranches fetches every page, want the first page only for drift")
+		}
+		return &entity.BranchComparison{
+			AheadBy: 8,
+			Files:   []entity.ChangedFile{{Filename: "main.go"}},
+		}, nil
+	}
+
+	uc := newTestListStatusUseCase(mockClient, 1)
+
+	statuses, err := uc.Execute(context.Background(), ListStatusInput{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	s := statuses[0]
+	if len(s.BranchCI) != 2 {
+		t.Fatalf("BranchCI = %+v, want production and staging", s.BranchCI)
+	}
+	if s.BranchCI[0].Branch != "production" || s.BranchCI[0].Conclusion != "failure" {
+		t.Errorf("BranchCI[0] = %+v, want production failure (latest completed run)", s.BranchCI[0])
+	}
+	if s.BranchCI[1].Branch != "staging" || s.BranchCI[1].Conclusion != "success" {
+		t.Errorf("BranchCI[1] = %+v, want staging success", s.BranchCI[1])
+	}
+	if !s.FailedCI {
+		t.Error("FailedCI = false, want true")
+	}
+
+	if len(mockClient.CompareBranchesCalls) != 1 {
+		t.Fatalf("CompareBranches called %d times, want 1", len(mockClient.CompareBranchesCalls))
+	}
+	call := mockClient.CompareBranchesCalls[0]
+	if call.Base != "production" || call.Head != "staging" {
+		t.Errorf("CompareBranches(%s, %s), want (production, staging)", call.Base, call.Head)
+	}
+	if !s.HasDrift {
+		t.Error("HasDrift = false, want true")
+	}
+	if s.DriftSeverity != "medium" {
+		t.Errorf("DriftSeverity = %s, want medium", s.DriftSeverity)
+	}
+	if s.HealthScore != 60 {
+		t.Errorf("HealthScore = %d, want 60 (100 - 25 CI - 15 drift)", s.HealthScore)
+	}
+}
+
+func TestListStatusUseCase_Execute_SortsAndFiltersByHealth(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListRepositoriesFunc = func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+		return []entity.Repository{
+			{FullName: "test/healthy", PushedAt: time.Now()},
+			{FullName: "test/broken", PushedAt: time.Now()},
+			{FullName: "test/flaky", PushedAt: time.Now()},
+		}, nil
+	}
+	mockClient.ListWorkflowRunsFunc = func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+		switch {
+		case filter.Repository == "test/broken":
+			return []entity.WorkflowRun{{Status: "completed", Conclusion: "failure"}}, nil
+		case filter.Repository == "test/flaky" && filter.Branch == "develop":
+			return []entity.WorkflowRun{{Status: "completed", Conclusion: "failure"}}, nil
+		}
+		return []entity.WorkflowRun{{Status: "completed", Conclusion: "success"}}, nil
+	}
+
+	uc := newTestListStatusUseCase(mockClient, 2)
+
+	statuses, err := uc.Execute(context.Background(), ListStatusInput{SortBy: StatusSortHealth})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := []string{"test/broken", "test/flaky", "test/healthy"}
+	for i, name := range want {
+		if statuses[i].Repository.FullName != name {
+			t.Errorf("statuses[%d] = %s (score %d), want %s", i, statuses[i].Repository.FullName, statuses[i].HealthScore, name)
+		}
+	}
+
+	maxHealth := 99
+	statuses, err = uc.Execute(context.Background(), ListStatusInput{MinHealth: 60, MaxHealth: &maxHealth})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Repository.FullName != "test/flaky" {
+		t.Errorf("filtered statuses = %+v, want only test/flaky", statuses)
+	}
+
+	maxHealth = 0
+	statuses, err = uc.Execute(context.Background(), ListStatusInput{MaxHealth: &maxHealth})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(statuses) != 0 {
+		t.Errorf("statuses with max health 0 = %d, want none", len(statuses))
+	}
+
+	if _, err := uc.Execute(context.Background(), ListStatusInput{SortBy: "stars"}); err == nil {
+		t.Error("Execute() error = nil, want error for unknown sort")
+	}
+}
+
+func TestListStatusUseCase_Execute_UnreachableRepositorySortsUnhealthy(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListRepositoriesFunc = func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+		return []entity.Repository{
+			{FullName: "test/healthy", PushedAt: time.Now()},
+			{FullName: "test/gone", PushedAt: time.Now()},
+		}, nil
+	}
+	mockClient.ListPullRequestsFunc = func(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+		if filter.Repository == "test/gone" {
+			return nil, port.ErrNotFound
+		}
+		return nil, nil
+	}
+	mockClient.ListWorkflowRunsFunc = func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+		if filter.Repository == "test/gone" {
+			return nil, port.ErrNotFound
+		}
+		return []entity.WorkflowRun{{Status: "completed", Conclusion: "success"}}, nil
+	}
+	mockClient.CompareBranchesFunc = func(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+		if repo == "gone" {
+			return nil, port.ErrNotFound
+		}
+		return &entity.BranchComparison{GitHubStatus: "identical"}, nil
+	}
+
+	uc := newTestListStatusUseCase(mockClient, 2)
+
+	statuses, err := uc.Execute(context.Background(), ListStatusInput{SortBy: StatusSortHealth})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if statuses[0].Repository.FullName != "test/gone" || statuses[0].HealthScore >= statuses[1].HealthScore {
+		t.Errorf("statuses = %s (%d), %s (%d); want test/gone scored below test/healthy",
+			statuses[0].Repository.FullName, statuses[0].HealthScore, statuses[1].Repository.FullName, statuses[1].HealthScore)
+	}
+}
+
+func TestListStatusUseCase_Execute_ReportsUnlistedAccounts(t *testing.T) {
+	partial := &port.PartialError{Errs: []error{fmt.Errorf("account acme: %w", port.ErrUnauthorized)}}
+
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListRepositoriesFunc = func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+		return testRepositories(2), partial
+	}
+
+	statuses, err := newTestListStatusUseCase(mockClient, 2).Execute(context.Background(), ListStatusInput{})
+	if !errors.Is(err, port.ErrUnauthorized) || !port.IsPartial(err) {
+		t.Errorf("Execute() error = %v, want the listing's partial error", err)
+	}
+	if len(statuses) != 2 {
+		t.Errorf("len(statuses) = %d, want 2 from the accounts that answered", len(statuses))
+	}
+}
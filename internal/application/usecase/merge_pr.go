@@ -10,11 +10,12 @@ import (
 )
 
 type MergePRUseCase struct {
-	client port.GitHubClient
+	client      port.GitHubClient
+	invalidator port.CacheInvalidator
 }
 
-func NewMergePRUseCase(client port.GitHubClient) *MergePRUseCase {
-	return &MergePRUseCase{client: client}
+func NewMergePRUseCase(client port.GitHubClient, invalidator port.CacheInvalidator) *MergePRUseCase {
+	return &MergePRUseCase{client: client, invalidator: invalidator}
 }
 
 type MergePRInput struct {
@@ -82,6 +83,7 @@ func (uc *MergePRUseCase) Execute(ctx context.Context, input MergePRInput) (*ent
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge PR #%d: %w", input.PRNumber, err)
 	}
+	uc.invalidator.InvalidateRepository(input.Repository)
 
 	result.BranchName = pr.HeadBranch
 
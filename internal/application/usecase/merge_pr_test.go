@@ -34,7 +34,7 @@ func TestMergePRUseCase_Execute_MergeSuccess(t *testing.T) {
 		}, nil
 	}
 
-	uc := NewMergePRUseCase(mockClient)
+	uc := NewMergePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), MergePRInput{
 		Repository: "test/repo",
@@ -72,7 +72,7 @@ func TestMergePRUseCase_Execute_SquashMethod(t *testing.T) {
 		return &entity.MergeResult{Success: true, SHA: "def456", MergeMethod: entity.MergeMethodSquash}, nil
 	}
 
-	uc := NewMergePRUseCase(mockClient)
+	uc := NewMergePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), MergePRInput{
 		Repository: "test/repo",
@@ -103,7 +103,7 @@ func TestMergePRUseCase_Execute_RebaseMethod(t *testing.T) {
 		return &entity.MergeResult{Success: true, SHA: "ghi789", MergeMethod: entity.MergeMethodRebase}, nil
 	}
 
-	uc := NewMergePRUseCase(mockClient)
+	uc := NewMergePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), MergePRInput{
 		Repository: "test/repo",
@@ -139,7 +139,7 @@ func TestMergePRUseCase_Execute_WithBranchDeletion(t *testing.T) {
 		return &entity.MergeResult{Success: true, SHA: "abc123", Message: "Merged"}, nil
 	}
 
-	uc := NewMergePRUseCase(mockClient)
+	uc := NewMergePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), MergePRInput{
 		Repository:   "test/repo",
@@ -182,7 +182,7 @@ func TestMergePRUseCase_Execute_BranchDeletionFails_NonFatal(t *testing.T) {
 		return errors.New("branch deletion failed")
 	}
 
-	uc := NewMergePRUseCase(mockClient)
+	uc := NewMergePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), MergePRInput{
 		Repository:   "test/repo",
@@ -220,7 +220,7 @@ func TestMergePRUseCase_Execute_DryRun(t *testing.T) {
 		}, nil
 	}
 
-	uc := NewMergePRUseCase(mockClient)
+	uc := NewMergePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	result, err := uc.Execute(context.Background(), MergePRInput{
 		Repository:   "test/repo",
@@ -256,7 +256,7 @@ func TestMergePRUseCase_Execute_PRNotOpen(t *testing.T) {
 		return &entity.PullRequest{Number: 1, State: "closed"}, nil
 	}
 
-	uc := NewMergePRUseCase(mockClient)
+	uc := NewMergePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), MergePRInput{
 		Repository: "test/repo",
@@ -278,7 +278,7 @@ func TestMergePRUseCase_Execute_PRHasConflicts(t *testing.T) {
 		return &entity.PullRequest{Number: 1, State: "open", Mergeable: boolPtr(false)}, nil
 	}
 
-	uc := NewMergePRUseCase(mockClient)
+	uc := NewMergePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), MergePRInput{
 		Repository: "test/repo",
@@ -296,7 +296,7 @@ func TestMergePRUseCase_Execute_PRHasConflicts(t *testing.T) {
 
 func TestMergePRUseCase_Execute_InvalidRepoFormat(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
-	uc := NewMergePRUseCase(mockClient)
+	uc := NewMergePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), MergePRInput{
 		Repository: "invalid",
@@ -310,7 +310,7 @@ func TestMergePRUseCase_Execute_InvalidRepoFormat(t *testing.T) {
 
 func TestMergePRUseCase_Execute_InvalidMethod(t *testing.T) {
 	mockClient := port.NewMockGitHubClient()
-	uc := NewMergePRUseCase(mockClient)
+	uc := NewMergePRUseCase(mockClient, port.NewMockCacheInvalidator())
 
 	_, err := uc.Execute(context.Background(), MergePRInput{
 		Repository: "test/repo",
@@ -326,3 +326,39 @@ func TestMergePRUseCase_Execute_InvalidMethod(t *testing.T) {
 		t.Errorf("error = %v, want to contain 'invalid merge method'", err)
 	}
 }
+
+func TestMergePRUseCase_Execute_InvalidatesCache(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetPullRequestFunc = func(ctx context.Context, owner, repo string, number int) (*entity.PullRequest, error) {
+		return &entity.PullRequest{Number: 42, State: "open", HeadBranch: "feature/test"}, nil
+	}
+	mockClient.MergePullRequestFunc = func(ctx context.Context, owner, repo string, number int, method, commitTitle string) (*entity.MergeResult, error) {
+		return &entity.MergeResult{Success: true, PRNumber: 42}, nil
+	}
+	invalidator := port.NewMockCacheInvalidator()
+
+	uc := NewMergePRUseCase(mockClient, invalidator)
+
+	_, err := uc.Execute(context.Background(), MergePRInput{
+		Repository: "test/repo",
+		PRNumber:   42,
+		DryRun:     true,
+	})
+	if err != nil {
+		t.Fatalf("dry run Execute() error = %v", err)
+	}
+	if len(invalidator.InvalidatedRepositories) != 0 {
+		t.Errorf("dry run invalidated %v, want nothing", invalidator.InvalidatedRepositories)
+	}
+
+	_, err = uc.Execute(context.Background(), MergePRInput{
+		Repository: "test/repo",
+		PRNumber:   42,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(invalidator.InvalidatedRepositories) != 1 || invalidator.InvalidatedRepositories[0] != "test/repo" {
+		t.Errorf("InvalidatedRepositories = %v, want [test/repo]", invalidator.InvalidatedRepositories)
+	}
+}
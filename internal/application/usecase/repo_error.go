@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+// newRepoError classifies err, a failure of operation on repository.
+func newRepoError(repository, operation string, err error) entity.RepoError {
+	kind := entity.ErrorOther
+	switch {
+	case errors.Is(err, port.ErrUnauthorized):
+		kind = entity.ErrorAuth
+	case errors.Is(err, port.ErrRateLimited):
+		kind = entity.ErrorRateLimit
+	case errors.Is(err, port.ErrNotFound):
+		kind = entity.ErrorNotFound
+	case errors.Is(err, port.ErrUnsupported):
+		kind = entity.ErrorUnsupported
+	}
+
+	return entity.RepoError{
+		Repository: repository,
+		Operation:  operation,
+		Kind:       kind,
+		Err:        err,
+	}
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+type ServerStatusUseCase struct {
+	client port.GitHubClient
+	api    port.APIStatusProvider
+	cache  port.CacheManager
+}
+
+func NewServerStatusUseCase(client port.GitHubClient, api port.APIStatusProvider, cache port.CacheManager) *ServerStatusUseCase {
+	return &ServerStatusUseCase{client: client, api: api, cache: cache}
+}
+
+// Execute gathers the server status. A failure to fetch the authenticated
+// user is reported in the status rather than returned, since it is often the
+// reason the status is being checked.
+func (uc *ServerStatusUseCase) Execute(ctx context.Context) (*entity.ServerStatus, error) {
+	status := &entity.ServerStatus{
+		RateLimit: uc.api.RateLimitStatus(),
+		Retries:   uc.api.RetryStats(),
+		Cache:     uc.cache.CacheStats(),
+	}
+
+	user, err := uc.client.GetCurrentUser(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		status.UserError = err.Error()
+	} else {
+		status.User = user
+	}
+
+	return status, nil
+}
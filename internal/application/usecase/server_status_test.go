@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+func TestServerStatusUseCase_Execute(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetCurrentUserFunc = func(ctx context.Context) (string, error) {
+		return "octocat", nil
+	}
+
+	resetAt := time.Now().Add(30 * time.Minute)
+	api := &port.MockAPIStatusProvider{
+		RateLimit: entity.RateLimitStatus{Remaining: 4200, ResetAt: resetAt, Threshold: 10},
+		Retries:   entity.RetryStats{Retries: 3, Recovered: 1, Exhausted: 1},
+	}
+	cacheManager := port.NewMockCacheManager()
+	cacheManager.Stats = entity.CacheStats{Hits: 7, Misses: 2, Entries: 5, Bytes: 2048}
+
+	uc := NewServerStatusUseCase(mockClient, api, cacheManager)
+
+	status, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if status.User != "octocat" {
+		t.Errorf("User = %s, want octocat", status.User)
+	}
+	if status.RateLimit != api.RateLimit {
+		t.Errorf("RateLimit = %+v, want %+v", status.RateLimit, api.RateLimit)
+	}
+	if status.Retries != api.Retries {
+		t.Errorf("Retries = %+v, want %+v", status.Retries, api.Retries)
+	}
+	if status.Cache != cacheManager.Stats {
+		t.Errorf("Cache = %+v, want %+v", status.Cache, cacheManager.Stats)
+	}
+}
+
+func TestServerStatusUseCase_Execute_UserError(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetCurrentUserFunc = func(ctx context.Context) (string, error) {
+		return "", port.ErrUnauthorized
+	}
+
+	uc := NewServerStatusUseCase(mockClient, &port.MockAPIStatusProvider{}, port.NewMockCacheManager())
+
+	status, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v, want the user error in the status", err)
+	}
+
+	if status.User != "" {
+		t.Errorf("User = %s, want empty", status.User)
+	}
+	if status.UserError != port.ErrUnauthorized.Error() {
+		t.Errorf("UserError = %s, want %s", status.UserError, port.ErrUnauthorized)
+	}
+}
+
+func TestServerStatusUseCase_Execute_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetCurrentUserFunc = func(ctx context.Context) (string, error) {
+		return "", ctx.Err()
+	}
+
+	uc := NewServerStatusUseCase(mockClient, &port.MockAPIStatusProvider{}, port.NewMockCacheManager())
+
+	_, err := uc.Execute(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Execute() error = %v, want context.Canceled", err)
+	}
+}
@@ -3,32 +3,65 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/carlos/mcp-repo-monitor/config"
 	"github.com/carlos/mcp-repo-monitor/internal/application/port"
 	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
 	"github.com/carlos/mcp-repo-monitor/internal/domain/service"
 )
 
+// Defaults for wait mode.
+const (
+	defaultRollbackWaitTimeout  = 10 * time.Minute
+	defaultRollbackPollInterval = 10 * time.Second
+
+	// rollbackRunSearchLimit bounds how many recent runs are scanned when
+	// looking for the run a rollback created.
+	rollbackRunSearchLimit = 30
+)
+
 type TriggerRollbackUseCase struct {
 	client          port.GitHubClient
+	config          *config.Config
 	rollbackService *service.RollbackService
+	invalidator     port.CacheInvalidator
+	pollInterval    time.Duration
+	now             func() time.Time
 }
 
-func NewTriggerRollbackUseCase(client port.GitHubClient, rollbackService *service.RollbackService) *TriggerRollbackUseCase {
+func NewTriggerRollbackUseCase(
+	client port.GitHubClient,
+	cfg *config.Config,
+	rollbackService *service.RollbackService,
+	invalidator port.CacheInvalidator,
+) *TriggerRollbackUseCase {
 	return &TriggerRollbackUseCase{
 		client:          client,
+		config:          cfg,
 		rollbackService: rollbackService,
+		invalidator:     invalidator,
+		pollInterval:    defaultRollbackPollInterval,
+		now:             time.Now,
 	}
 }
 
+// ProgressFunc receives progress updates while a rollback is being awaited.
+// progress and total are in seconds.
+type ProgressFunc func(progress, total float64, message string)
+
 type TriggerRollbackInput struct {
-	Repository string
-	Strategy   string
-	WorkflowID int64
-	RunID      int64
-	CommitSHA  string
-	DryRun     bool
+	Repository  string
+	Strategy    string
+	WorkflowID  int64
+	RunID       int64
+	CommitSHA   string
+	DryRun      bool
+	Wait        bool          // Poll until the triggered run completes
+	WaitTimeout time.Duration // Defaults to 10 minutes
+	OnProgress  ProgressFunc
 }
 
 func (uc *TriggerRollbackUseCase) Execute(ctx context.Context, input TriggerRollbackInput) (*entity.RollbackResult, error) {
@@ -40,72 +73,447 @@ func (uc *TriggerRollbackUseCase) Execute(ctx context.Context, input TriggerRoll
 
 	strategy := entity.RollbackStrategy(input.Strategy)
 
+	var decision *entity.StrategyDecision
+	if strategy == entity.RollbackAuto {
+		var err error
+		decision, err = uc.selectStrategy(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		strategy = decision.Strategy
+		input.RunID = decision.RunID
+		input.CommitSHA = decision.CommitSHA
+	}
+
+	result, err := uc.execute(ctx, owner, repo, strategy, input)
+	if err != nil {
+		return nil, err
+	}
+	result.Decision = decision
+
+	return result, nil
+}
+
+// selectStrategy chooses a strategy from the prod branch's recent CI runs and
+// commits.
+func (uc *TriggerRollbackUseCase) selectStrategy(ctx context.Context, input TriggerRollbackInput) (*entity.StrategyDecision, error) {
+	prodBranch := uc.config.GetBranchConfig(input.Repository).ProdBranch
+
+	runs, err := uc.client.ListWorkflowRuns(ctx, entity.CIFilter{
+		Repository: input.Repository,
+		Branch:     prodBranch,
+		Limit:      rollbackRunSearchLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs on %s: %w", prodBranch, err)
+	}
+
+	commits, err := uc.client.ListCommits(ctx, entity.CommitFilter{
+		Repository: input.Repository,
+		Branch:     prodBranch,
+		Limit:      rollbackRunSearchLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits on %s: %w", prodBranch, err)
+	}
+
+	decision := uc.rollbackService.DetermineStrategy(runs, commits, uc.rollbackWorkflow(input) != "")
+	if decision.Strategy == "" {
+		return nil, fmt.Errorf("%w: %s", service.ErrNoStrategy, strings.Join(decision.Reasons, "; "))
+	}
+
+	return &decision, nil
+}
+
+// execute validates, plans and runs a rollback with a concrete strategy.
+func (uc *TriggerRollbackUseCase) execute(ctx context.Context, owner, repo string, strategy entity.RollbackStrategy, input TriggerRollbackInput) (*entity.RollbackResult, error) {
+	err := uc.rollbackService.ValidateRequest(entity.RollbackRequest{
+		Repository: input.Repository,
+		Strategy:   strategy,
+		WorkflowID: input.WorkflowID,
+		Workflow:   uc.rollbackWorkflow(input),
+		RunID:      input.RunID,
+		CommitSHA:  input.CommitSHA,
+		DryRun:     input.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.plan(ctx, owner, repo, strategy, input)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.DryRun {
 		return &entity.RollbackResult{
 			Success:  true,
 			Strategy: strategy,
-			Message:  fmt.Sprintf("[DRY RUN] Would execute %s rollback on %s", strategy, input.Repository),
+			Message:  "[DRY RUN] " + describePlan(strategy, plan),
+			Plan:     plan,
 		}, nil
 	}
 
+	release, err := uc.rollbackService.Begin(input.Repository)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	switch strategy {
 	case entity.RollbackRerun:
-		if input.RunID == 0 {
-			runs, err := uc.client.ListWorkflowRuns(ctx, entity.CIFilter{
-				Repository: input.Repository,
-				Limit:      1,
-			})
-			if err != nil {
-				return nil, err
-			}
-			if len(runs) == 0 {
-				return nil, fmt.Errorf("no workflow runs found")
-			}
-			input.RunID = runs[0].ID
+		return uc.rerun(ctx, owner, repo, input, plan)
+	case entity.RollbackWorkflow:
+		return uc.dispatchWorkflow(ctx, owner, repo, input, plan)
+	default:
+		return uc.revert(ctx, owner, repo, plan)
+	}
+}
+
+// plan resolves every input of the rollback against the API without
+// changing anything, so dry runs and real runs act on the same targets.
+func (uc *TriggerRollbackUseCase) plan(ctx context.Context, owner, repo string, strategy entity.RollbackStrategy, input TriggerRollbackInput) (*entity.RollbackPlan, error) {
+	switch strategy {
+	case entity.RollbackRerun:
+		return uc.planRerun(ctx, owner, repo, input)
+	case entity.RollbackWorkflow:
+		return uc.planWorkflow(ctx, owner, repo, input)
+	case entity.RollbackRevert:
+		return uc.planRevert(ctx, owner, repo, input)
+	default:
+		return nil, fmt.Errorf("%w: %s", service.ErrUnknownStrategy, strategy)
+	}
+}
+
+// planRerun picks input.RunID, or the latest run on the prod branch when no
+// ID is given.
+func (uc *TriggerRollbackUseCase) planRerun(ctx context.Context, owner, repo string, input TriggerRollbackInput) (*entity.RollbackPlan, error) {
+	var run *entity.WorkflowRun
+
+	if input.RunID == 0 {
+		runs, err := uc.client.ListWorkflowRuns(ctx, entity.CIFilter{
+			Repository: input.Repository,
+			Branch:     uc.config.GetBranchConfig(input.Repository).ProdBranch,
+			Limit:      1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(runs) == 0 {
+			return nil, fmt.Errorf("no workflow runs found")
+		}
+		run = &runs[0]
+	} else {
+		var err error
+		run, err = uc.client.GetWorkflowRun(ctx, owner, repo, input.RunID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get run %d: %w", input.RunID, err)
 		}
+	}
+
+	if err := uc.rollbackService.ValidateRerunTarget(*run); err != nil {
+		return nil, err
+	}
+
+	return &entity.RollbackPlan{Run: run}, nil
+}
+
+// planWorkflow checks the rollback workflow exists and can be dispatched.
+// An explicit workflow_id wins over the repository's rollback config.
+func (uc *TriggerRollbackUseCase) planWorkflow(ctx context.Context, owner, repo string, input TriggerRollbackInput) (*entity.RollbackPlan, error) {
+	workflowID := uc.rollbackWorkflow(input)
+	workflow, err := uc.client.GetWorkflow(ctx, owner, repo, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow %s: %w", workflowID, err)
+	}
+
+	plan := &entity.RollbackPlan{Workflow: workflow}
+
+	if rollbackCfg := uc.config.GetBranchConfig(input.Repository).Rollback; input.WorkflowID == 0 && rollbackCfg != nil {
+		plan.Ref = rollbackCfg.Ref
+		plan.Inputs = rollbackCfg.Inputs
+	}
 
-		err := uc.client.RerunWorkflow(ctx, owner, repo, input.RunID)
+	if plan.Ref == "" {
+		repoInfo, err := uc.client.GetRepository(ctx, owner, repo)
 		if err != nil {
 			return nil, err
 		}
+		plan.Ref = repoInfo.DefaultBranch
+	}
 
-		return &entity.RollbackResult{
-			Success:  true,
-			Strategy: strategy,
-			Message:  fmt.Sprintf("Rerun triggered for run ID %d", input.RunID),
-			RunURL:   fmt.Sprintf("https://github.com/%s/actions/runs/%d", input.Repository, input.RunID),
-		}, nil
+	if workflow.State != "" && workflow.State != "active" {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("workflow %s is %s", workflow.Name, workflow.State))
+	}
+
+	return plan, nil
+}
+
+// rollbackWorkflow returns the workflow the workflow strategy dispatches:
+// input.WorkflowID when set, otherwise the workflow file from repos.json.
+func (uc *TriggerRollbackUseCase) rollbackWorkflow(input TriggerRollbackInput) string {
+	if input.WorkflowID != 0 {
+		return strconv.FormatInt(input.WorkflowID, 10)
+	}
+	if rollbackCfg := uc.config.GetBranchConfig(input.Repository).Rollback; rollbackCfg != nil {
+		return rollbackCfg.Workflow
+	}
+	return ""
+}
+
+// planRevert loads the commit to revert and the branches involved. Merge
+// commits are reverted against their first parent. The revert branch is
+// named after the commit and the time, so retrying a rollback whose branch
+// is still around does not collide with it. A provider that cannot revert
+// is refused here, before the rollback creates a branch.
+func (uc *TriggerRollbackUseCase) planRevert(ctx context.Context, owner, repo string, input TriggerRollbackInput) (*entity.RollbackPlan, error) {
+	if err := uc.client.CheckRevert(ctx, owner, repo); err != nil {
+		return nil, err
+	}
+
+	commit, err := uc.client.GetCommit(ctx, owner, repo, input.CommitSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", input.CommitSHA, err)
+	}
+
+	plan := &entity.RollbackPlan{
+		Commit:     commit,
+		Branch:     fmt.Sprintf("revert-%.7s-%s", commit.SHA, uc.now().UTC().Format("20060102150405")),
+		BaseBranch: uc.config.GetBranchConfig(input.Repository).ProdBranch,
+	}
 
+	if len(commit.Parents) > 1 {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%.7s is a merge commit, reverted against its first parent %.7s", commit.SHA, commit.Parents[0]))
+	}
+
+	return plan, nil
+}
+
+// describePlan summarizes a plan in one line.
+func describePlan(strategy entity.RollbackStrategy, plan *entity.RollbackPlan) string {
+	switch strategy {
+	case entity.RollbackRerun:
+		return fmt.Sprintf("Would rerun run %d (%s) on %s at %.7s, last conclusion: %s",
+			plan.Run.ID, plan.Run.Name, plan.Run.HeadBranch, plan.Run.HeadSHA, plan.Run.Conclusion)
 	case entity.RollbackWorkflow:
-		if input.WorkflowID == 0 {
-			return nil, fmt.Errorf("workflow_id required for workflow strategy")
+		return fmt.Sprintf("Would dispatch workflow %s (%s) on %s", plan.Workflow.Name, plan.Workflow.Path, plan.Ref)
+	default:
+		return fmt.Sprintf("Would revert %.7s (%s) on %s via branch %s",
+			plan.Commit.SHA, strings.Split(plan.Commit.Message, "\n")[0], plan.BaseBranch, plan.Branch)
+	}
+}
+
+// rerun re-runs the run chosen by planRerun.
+func (uc *TriggerRollbackUseCase) rerun(ctx context.Context, owner, repo string, input TriggerRollbackInput, plan *entity.RollbackPlan) (*entity.RollbackResult, error) {
+	previous := plan.Run
+
+	err := uc.client.RerunWorkflow(ctx, owner, repo, previous.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.RollbackResult{
+		Success:  true,
+		Strategy: entity.RollbackRerun,
+		Message:  fmt.Sprintf("Rerun triggered for run ID %d", previous.ID),
+		RunURL:   previous.HTMLURL,
+		RunID:    previous.ID,
+		Plan:     plan,
+	}
+
+	if !input.Wait {
+		return result, nil
+	}
+
+	// A rerun keeps its run ID and bumps RunAttempt. Providers without
+	// attempt numbers, such as GitLab retrying a pipeline, report the same
+	// attempt, so a run updated since the rerun counts as well. Until then
+	// the run still shows the previous attempt.
+	poll := func(ctx context.Context) (*entity.WorkflowRun, error) {
+		run, err := uc.client.GetWorkflowRun(ctx, owner, repo, previous.ID)
+		if err != nil {
+			return nil, err
+		}
+		if run.RunAttempt > previous.RunAttempt ||
+			(run.RunAttempt == previous.RunAttempt && run.UpdatedAt.After(previous.UpdatedAt)) {
+			return run, nil
 		}
+		return nil, nil
+	}
 
-		repoInfo, err := uc.client.GetRepository(ctx, owner, repo)
+	return uc.awaitRun(ctx, input, poll, result)
+}
+
+// dispatchWorkflow fires a workflow_dispatch event for the planned workflow.
+func (uc *TriggerRollbackUseCase) dispatchWorkflow(ctx context.Context, owner, repo string, input TriggerRollbackInput, plan *entity.RollbackPlan) (*entity.RollbackResult, error) {
+	workflowFile := uc.rollbackWorkflow(input)
+	filter := entity.CIFilter{
+		Repository: input.Repository,
+		Branch:     plan.Ref,
+		Workflow:   workflowFile,
+		Limit:      rollbackRunSearchLimit,
+	}
+
+	// Dispatch does not return the run it creates, so remember which runs
+	// already existed and wait for one that did not.
+	known := make(map[int64]bool)
+	if input.Wait {
+		runs, err := uc.client.ListWorkflowRuns(ctx, filter)
 		if err != nil {
 			return nil, err
 		}
+		for _, run := range runs {
+			known[run.ID] = true
+		}
+	}
+
+	err := uc.client.TriggerWorkflow(ctx, owner, repo, workflowFile, plan.Ref, plan.Inputs)
+	if err != nil {
+		return nil, err
+	}
 
-		workflowFile := fmt.Sprintf("%d", input.WorkflowID)
-		err = uc.client.TriggerWorkflow(ctx, owner, repo, workflowFile, repoInfo.DefaultBranch)
+	result := &entity.RollbackResult{
+		Success:  true,
+		Strategy: entity.RollbackWorkflow,
+		Message:  fmt.Sprintf("Workflow %s triggered on %s", plan.Workflow.Name, plan.Ref),
+		Plan:     plan,
+	}
+
+	if !input.Wait {
+		return result, nil
+	}
+
+	// Once a new dispatched run shows up, follow that one.
+	var trackedID int64
+	poll := func(ctx context.Context) (*entity.WorkflowRun, error) {
+		runs, err := uc.client.ListWorkflowRuns(ctx, filter)
 		if err != nil {
 			return nil, err
 		}
+		for i := range runs {
+			if (trackedID != 0 && runs[i].ID == trackedID) ||
+				(trackedID == 0 && !known[runs[i].ID] && runs[i].Event == "workflow_dispatch") {
+				trackedID = runs[i].ID
+				return &runs[i], nil
+			}
+		}
+		return nil, nil
+	}
 
-		return &entity.RollbackResult{
-			Success:  true,
-			Strategy: strategy,
-			Message:  fmt.Sprintf("Workflow %d triggered on %s", input.WorkflowID, repoInfo.DefaultBranch),
-		}, nil
+	return uc.awaitRun(ctx, input, poll, result)
+}
 
-	case entity.RollbackRevert:
-		return &entity.RollbackResult{
-			Success:  false,
-			Strategy: strategy,
-			Message:  "Revert strategy requires manual intervention - create a revert commit through the GitHub UI or CLI",
-		}, nil
+// awaitRun calls poll until the run it returns completes or the wait times
+// out, and records its outcome in result. poll returns nil while the run has
+// not started.
+func (uc *TriggerRollbackUseCase) awaitRun(
+	ctx context.Context,
+	input TriggerRollbackInput,
+	poll func(context.Context) (*entity.WorkflowRun, error),
+	result *entity.RollbackResult,
+) (*entity.RollbackResult, error) {
+	timeout := input.WaitTimeout
+	if timeout <= 0 {
+		timeout = defaultRollbackWaitTimeout
+	}
 
-	default:
-		return nil, fmt.Errorf("unknown rollback strategy: %s", strategy)
+	start := uc.now()
+	deadline := start.Add(timeout)
+	ticker := time.NewTicker(uc.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		tracked, err := poll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to poll workflow runs: %w", err)
+		}
+
+		status := "waiting for run to start"
+		if tracked != nil {
+			result.RunID = tracked.ID
+			result.RunURL = tracked.HTMLURL
+			status = fmt.Sprintf("run %d is %s", tracked.ID, tracked.Status)
+
+			if tracked.Status == "completed" {
+				result.Conclusion = tracked.Conclusion
+				result.Duration = runDuration(*tracked)
+				result.Success = tracked.Conclusion == "success"
+				result.Message = fmt.Sprintf("Run %d completed with conclusion %s after %s", tracked.ID, tracked.Conclusion, result.Duration.Round(time.Second))
+				return result, nil
+			}
+		}
+
+		if input.OnProgress != nil {
+			input.OnProgress(uc.now().Sub(start).Seconds(), timeout.Seconds(), status)
+		}
+
+		if uc.now().After(deadline) {
+			result.Success = false
+			result.Message = fmt.Sprintf("Timed out after %s (%s)", timeout, status)
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDuration measures the latest attempt of a completed run.
+func runDuration(run entity.WorkflowRun) time.Duration {
+	started := run.RunStartedAt
+	if started.IsZero() {
+		started = run.CreatedAt
+	}
+	if started.IsZero() || run.UpdatedAt.Before(started) {
+		return 0
 	}
+	return run.UpdatedAt.Sub(started)
+}
+
+// revert opens a PR against the prod branch that reverts the planned commit.
+func (uc *TriggerRollbackUseCase) revert(ctx context.Context, owner, repo string, plan *entity.RollbackPlan) (*entity.RollbackResult, error) {
+	commit := plan.Commit
+	branch := plan.Branch
+	subject := strings.Split(commit.Message, "\n")[0]
+
+	if err := uc.client.CreateBranch(ctx, owner, repo, branch, plan.BaseBranch); err != nil {
+		return nil, fmt.Errorf("failed to create branch '%s': %w", branch, err)
+	}
+
+	if _, err := uc.client.RevertCommit(ctx, owner, repo, branch, commit.SHA); err != nil {
+		uc.cleanupBranch(ctx, owner, repo, branch)
+		return nil, fmt.Errorf("failed to create revert commit: %w", err)
+	}
+
+	title := fmt.Sprintf("Revert \"%s\"", subject)
+	body := fmt.Sprintf("## Rollback\n\nThis PR reverts %s on `%s`.\n\n### Changes\n- **Files changed**: %d\n\n---\n_Created by mcp-repo-monitor_",
+		commit.SHA,
+		plan.BaseBranch,
+		len(commit.Files),
+	)
+
+	pr, err := uc.client.CreatePullRequest(ctx, owner, repo, title, body, branch, plan.BaseBranch, false)
+	if err != nil {
+		uc.cleanupBranch(ctx, owner, repo, branch)
+		return nil, fmt.Errorf("failed to create PR: %w", err)
+	}
+	uc.invalidator.InvalidateRepository(owner + "/" + repo)
+
+	return &entity.RollbackResult{
+		Success:  true,
+		Strategy: entity.RollbackRevert,
+		Message:  fmt.Sprintf("Created revert PR #%d for commit %.7s", pr.Number, commit.SHA),
+		PRURL:    pr.HTMLURL,
+		Branch:   branch,
+		Plan:     plan,
+	}, nil
+}
+
+// cleanupBranch removes a revert branch left behind by a failed rollback.
+// Failure is ignored: the caller already reports the original error.
+func (uc *TriggerRollbackUseCase) cleanupBranch(ctx context.Context, owner, repo, branch string) {
+	_ = uc.client.DeleteBranch(ctx, owner, repo, branch)
 }
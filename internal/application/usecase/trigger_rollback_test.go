@@ -0,0 +1,848 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/config"
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/service"
+)
+
+func newRollbackTestConfig() *config.Config {
+	return &config.Config{
+		ReposConfig: config.ReposConfig{
+			Default: config.BranchConfig{
+				ProdBranch: "main",
+				DevBranch:  "develop",
+			},
+			Repositories: map[string]config.BranchConfig{
+				"custom/repo": {
+					ProdBranch: "production",
+					DevBranch:  "staging",
+				},
+			},
+		},
+	}
+}
+
+func TestTriggerRollbackUseCase_Revert_Success(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetCommitFunc = func(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+		return &entity.Commit{
+			SHA:     "abc1234def",
+			Message: "feat: break everything\n\nlong description",
+			Parents: []string{"parent1"},
+			Files:   []entity.ChangedFile{{Filename: "main.go", Status: "modified"}},
+		}, nil
+	}
+	mockClient.CreatePullRequestFunc = func(ctx context.Context, owner, repo, title, body, head, base string, draft bool) (*entity.PullRequest, error) {
+		return &entity.PullRequest{Number: 7, HTMLURL: "https://github.com/custom/repo/pull/7"}, nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "custom/repo",
+		Strategy:   "revert",
+		CommitSHA:  "abc1234def",
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !result.Success {
+		t.Error("Success = false, want true")
+	}
+
+	if result.PRURL != "https://github.com/custom/repo/pull/7" {
+		t.Errorf("PRURL = %s, want https://github.com/custom/repo/pull/7", result.PRURL)
+	}
+
+	if len(mockClient.CreateBranchCalls) != 1 {
+		t.Fatalf("CreateBranch called %d times, want 1", len(mockClient.CreateBranchCalls))
+	}
+	branchCall := mockClient.CreateBranchCalls[0]
+	if branchCall.FromBranch != "production" {
+		t.Errorf("CreateBranch from = %s, want production (configured prod branch)", branchCall.FromBranch)
+	}
+	if !strings.HasPrefix(branchCall.Branch, "revert-abc1234-") {
+		t.Errorf("CreateBranch branch = %s, want revert-abc1234-<time>", branchCall.Branch)
+	}
+
+	if len(mockClient.RevertCommitCalls) != 1 {
+		t.Fatalf("RevertCommit called %d times, want 1", len(mockClient.RevertCommitCalls))
+	}
+	if mockClient.RevertCommitCalls[0].Branch != branchCall.Branch {
+		t.Errorf("RevertCommit branch = %s, want %s", mockClient.RevertCommitCalls[0].Branch, branchCall.Branch)
+	}
+
+	prCall := mockClient.CreatePRCalls[0]
+	if prCall.Head != branchCall.Branch || prCall.Base != "production" {
+		t.Errorf("CreatePullRequest head=%s base=%s, want head=%s base=production", prCall.Head, prCall.Base, branchCall.Branch)
+	}
+	if prCall.Title != `Revert "feat: break everything"` {
+		t.Errorf("CreatePullRequest title = %s, want Revert \"feat: break everything\"", prCall.Title)
+	}
+}
+
+func TestTriggerRollbackUseCase_Revert_DryRun(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetCommitFunc = func(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+		return &entity.Commit{
+			SHA:     sha,
+			Message: "fix: something",
+			Parents: []string{"parent1"},
+			Files: []entity.ChangedFile{
+				{Filename: "a.go", Status: "modified"},
+				{Filename: "b.go", Status: "added"},
+			},
+		}, nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "revert",
+		CommitSHA:  "deadbeef",
+		DryRun:     true,
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !strings.Contains(result.Message, "DRY RUN") {
+		t.Errorf("Message = %s, want to contain 'DRY RUN'", result.Message)
+	}
+
+	if result.Plan == nil || result.Plan.Commit == nil {
+		t.Fatal("Plan.Commit = nil, want resolved commit")
+	}
+	if len(result.Plan.Commit.Files) != 2 {
+		t.Errorf("Plan.Commit.Files = %d, want 2", len(result.Plan.Commit.Files))
+	}
+	if result.Plan.BaseBranch != "main" {
+		t.Errorf("Plan.BaseBranch = %s, want main", result.Plan.BaseBranch)
+	}
+
+	if len(mockClient.CreateBranchCalls) != 0 {
+		t.Error("CreateBranch should not be called in dry run mode")
+	}
+	if len(mockClient.RevertCommitCalls) != 0 {
+		t.Error("RevertCommit should not be called in dry run mode")
+	}
+	if len(mockClient.CreatePRCalls) != 0 {
+		t.Error("CreatePullRequest should not be called in dry run mode")
+	}
+}
+
+func TestTriggerRollbackUseCase_Revert_MissingCommitSHA(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	_, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "revert",
+	})
+
+	if err == nil {
+		t.Fatal("Execute() error = nil, want error")
+	}
+
+	if !strings.Contains(err.Error(), "commit_sha") {
+		t.Errorf("error = %v, want to contain 'commit_sha'", err)
+	}
+}
+
+func TestTriggerRollbackUseCase_Revert_MergeCommit(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetCommitFunc = func(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+		return &entity.Commit{SHA: sha, Parents: []string{"p1", "p2"}}, nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "revert",
+		CommitSHA:  "abc123",
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(result.Plan.Warnings) != 1 || !strings.Contains(result.Plan.Warnings[0], "first parent p1") {
+		t.Errorf("Plan.Warnings = %v, want first parent warning", result.Plan.Warnings)
+	}
+
+	if len(mockClient.RevertCommitCalls) != 1 || mockClient.RevertCommitCalls[0].SHA != "abc123" {
+		t.Errorf("RevertCommit calls = %+v, want one revert of abc123", mockClient.RevertCommitCalls)
+	}
+}
+
+func TestTriggerRollbackUseCase_Revert_BranchNamesAreUnique(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetCommitFunc = func(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+		return &entity.Commit{SHA: "abc1234", Parents: []string{"p1"}}, nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	input := TriggerRollbackInput{Repository: "test/repo", Strategy: "revert", CommitSHA: "abc1234", DryRun: true}
+	first, err := uc.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	now = now.Add(time.Minute)
+	second, err := uc.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if first.Plan.Branch != "revert-abc1234-20260101100000" {
+		t.Errorf("Plan.Branch = %s, want revert-abc1234-20260101100000", first.Plan.Branch)
+	}
+	if first.Plan.Branch == second.Plan.Branch {
+		t.Errorf("Plan.Branch = %s twice, want a fresh branch per attempt", first.Plan.Branch)
+	}
+}
+
+func TestTriggerRollbackUseCase_Revert_CleansUpBranchOnFailure(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetCommitFunc = func(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+		return &entity.Commit{SHA: "abc1234", Parents: []string{"p1"}}, nil
+	}
+	mockClient.RevertCommitFunc = func(ctx context.Context, owner, repo, branch, sha string) (*entity.Commit, error) {
+		return nil, errors.New("revert conflicts with later changes")
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	_, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "revert",
+		CommitSHA:  "abc1234",
+	})
+
+	if err == nil {
+		t.Fatal("Execute() error = nil, want error")
+	}
+
+	if len(mockClient.DeleteBranchCalls) != 1 {
+		t.Fatalf("DeleteBranch called %d times, want 1 (cleanup)", len(mockClient.DeleteBranchCalls))
+	}
+	if mockClient.DeleteBranchCalls[0].Branch != mockClient.CreateBranchCalls[0].Branch {
+		t.Errorf("DeleteBranch branch = %s, want %s", mockClient.DeleteBranchCalls[0].Branch, mockClient.CreateBranchCalls[0].Branch)
+	}
+
+	if len(mockClient.CreatePRCalls) != 0 {
+		t.Error("CreatePullRequest should not be called when revert fails")
+	}
+}
+
+func TestTriggerRollbackUseCase_Revert_UnsupportedProviderRefusedWhilePlanning(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.CheckRevertFunc = func(ctx context.Context, owner, repo string) error {
+		return &port.UnsupportedError{Provider: "gitea", Operation: "reverting commits"}
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	for _, dryRun := range []bool{true, false} {
+		_, err := uc.Execute(context.Background(), TriggerRollbackInput{
+			Repository: "test/repo",
+			Strategy:   "revert",
+			CommitSHA:  "abc1234",
+			DryRun:     dryRun,
+		})
+		if !errors.Is(err, port.ErrUnsupported) {
+			t.Errorf("Execute(dry run %v) error = %v, want ErrUnsupported", dryRun, err)
+		}
+	}
+
+	if len(mockClient.CreateBranchCalls) != 0 || len(mockClient.DeleteBranchCalls) != 0 {
+		t.Errorf("created %d and deleted %d branches, want none", len(mockClient.CreateBranchCalls), len(mockClient.DeleteBranchCalls))
+	}
+}
+
+func TestTriggerRollbackUseCase_Rerun_Wait(t *testing.T) {
+	started := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	polls := 0
+
+	failed := entity.WorkflowRun{
+		ID:         99,
+		HeadBranch: "main",
+		RunAttempt: 1,
+		Status:     "completed",
+		Conclusion: "failure",
+		HTMLURL:    "https://github.com/test/repo/actions/runs/99",
+	}
+
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListWorkflowRunsFunc = func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+		if filter.Branch != "main" {
+			t.Errorf("ListWorkflowRuns() Branch = %q, want the prod branch main", filter.Branch)
+		}
+		return []entity.WorkflowRun{failed}, nil
+	}
+	mockClient.GetWorkflowRunFunc = func(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+		if runID != 99 {
+			t.Errorf("GetWorkflowRun() runID = %d, want 99", runID)
+		}
+		polls++
+		run := failed
+		switch {
+		case polls == 2:
+			run.RunAttempt = 2
+			run.Status = "in_progress"
+			run.Conclusion = ""
+		case polls > 2:
+			run.RunAttempt = 2
+			run.Conclusion = "success"
+			run.RunStartedAt = started
+			run.UpdatedAt = started.Add(90 * time.Second)
+		}
+		return &run, nil
+	}
+
+	var progress []string
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+	uc.pollInterval = time.Millisecond
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "rerun",
+		Wait:       true,
+		OnProgress: func(_, _ float64, message string) {
+			progress = append(progress, message)
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// The first poll sees the previous (failed) attempt and must not stop there.
+	if result.Conclusion != "success" {
+		t.Errorf("Conclusion = %s, want success", result.Conclusion)
+	}
+	if !result.Success {
+		t.Error("Success = false, want true")
+	}
+	if result.Duration != 90*time.Second {
+		t.Errorf("Duration = %s, want 1m30s", result.Duration)
+	}
+	if result.RunURL != "https://github.com/test/repo/actions/runs/99" {
+		t.Errorf("RunURL = %s, want run HTML URL", result.RunURL)
+	}
+	if len(progress) == 0 {
+		t.Error("expected progress updates while waiting")
+	}
+}
+
+func TestTriggerRollbackUseCase_Rerun_WaitWithoutAttemptNumbers(t *testing.T) {
+	failedAt := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	retriedAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	polls := 0
+
+	// GitLab retries a pipeline in place and reports no attempt number, so
+	// every poll says attempt 1 and only the status and update time move.
+	// The first call plans the rerun.
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetWorkflowRunFunc = func(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+		polls++
+		run := entity.WorkflowRun{ID: runID, HeadBranch: "main", RunAttempt: 1, Status: "completed", Conclusion: "failure", UpdatedAt: failedAt}
+		switch {
+		case polls == 3:
+			run.Status = "in_progress"
+			run.Conclusion = ""
+			run.RunStartedAt = retriedAt
+			run.UpdatedAt = retriedAt
+		case polls > 3:
+			run.Conclusion = "success"
+			run.RunStartedAt = retriedAt
+			run.UpdatedAt = retriedAt.Add(2 * time.Minute)
+		}
+		return &run, nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+	uc.pollInterval = time.Millisecond
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository:  "test/repo",
+		Strategy:    "rerun",
+		RunID:       42,
+		Wait:        true,
+		WaitTimeout: 5 * time.Second,
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// The first poll still shows the failed pipeline and must not stop there.
+	if !result.Success || result.Conclusion != "success" {
+		t.Errorf("result = %+v, want the retried pipeline's success", result)
+	}
+	if result.Duration != 2*time.Minute {
+		t.Errorf("Duration = %s, want 2m0s", result.Duration)
+	}
+	if polls < 4 {
+		t.Errorf("polled %d times, want to wait for the retry to finish", polls-1)
+	}
+}
+
+func TestTriggerRollbackUseCase_Workflow_WaitFindsDispatchedRun(t *testing.T) {
+	dispatched := false
+
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetRepositoryFunc = func(ctx context.Context, owner, repo string) (*entity.Repository, error) {
+		return &entity.Repository{DefaultBranch: "main"}, nil
+	}
+	mockClient.TriggerWorkflowFunc = func(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error {
+		dispatched = true
+		return nil
+	}
+	mockClient.ListWorkflowRunsFunc = func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+		old := entity.WorkflowRun{ID: 1, Event: "workflow_dispatch", Status: "completed", Conclusion: "success"}
+		if !dispatched {
+			return []entity.WorkflowRun{old}, nil
+		}
+		return []entity.WorkflowRun{
+			{ID: 2, Event: "workflow_dispatch", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/test/repo/actions/runs/2"},
+			old,
+		}, nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+	uc.pollInterval = time.Millisecond
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "workflow",
+		WorkflowID: 123,
+		Wait:       true,
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if result.RunID != 2 {
+		t.Errorf("RunID = %d, want 2 (the newly dispatched run)", result.RunID)
+	}
+	if result.Conclusion != "failure" {
+		t.Errorf("Conclusion = %s, want failure", result.Conclusion)
+	}
+	if result.Success {
+		t.Error("Success = true, want false for failed run")
+	}
+	if result.RunURL == "" {
+		t.Error("RunURL is empty, want URL of dispatched run")
+	}
+}
+
+func TestTriggerRollbackUseCase_Wait_Timeout(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListWorkflowRunsFunc = func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+		return []entity.WorkflowRun{{ID: 5, RunAttempt: 1, Status: "completed", Conclusion: "failure"}}, nil
+	}
+	polls := 0
+	mockClient.GetWorkflowRunFunc = func(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+		polls++
+		return &entity.WorkflowRun{ID: runID, RunAttempt: 1, Status: "completed", Conclusion: "failure"}, nil
+	}
+
+	// Every reading of the clock moves it on by a minute.
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	var elapsed []float64
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+	uc.pollInterval = time.Millisecond
+	uc.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository:  "test/repo",
+		Strategy:    "rerun",
+		Wait:        true,
+		WaitTimeout: 5 * time.Minute,
+		OnProgress: func(progress, _ float64, _ string) {
+			elapsed = append(elapsed, progress)
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if result.Success {
+		t.Error("Success = true, want false on timeout")
+	}
+	if !strings.Contains(result.Message, "Timed out") {
+		t.Errorf("Message = %s, want to contain 'Timed out'", result.Message)
+	}
+	if polls != 3 {
+		t.Errorf("polled %d times, want 3 before the injected clock passes the deadline", polls)
+	}
+	if len(elapsed) == 0 || elapsed[0] != 60 {
+		t.Errorf("progress elapsed = %v, want to start at 60s on the injected clock", elapsed)
+	}
+}
+
+func TestTriggerRollbackUseCase_Rerun_DryRunResolvesLatestRun(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListWorkflowRunsFunc = func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+		return []entity.WorkflowRun{{
+			ID:         321,
+			Name:       "deploy",
+			HeadBranch: "main",
+			HeadSHA:    "cafebabe1234",
+			Status:     "completed",
+			Conclusion: "failure",
+		}}, nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "rerun",
+		DryRun:     true,
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if result.Plan == nil || result.Plan.Run == nil {
+		t.Fatal("Plan.Run = nil, want resolved run")
+	}
+	if result.Plan.Run.ID != 321 {
+		t.Errorf("Plan.Run.ID = %d, want 321", result.Plan.Run.ID)
+	}
+	if !strings.Contains(result.Message, "cafebab") {
+		t.Errorf("Message = %s, want to contain short SHA", result.Message)
+	}
+}
+
+func TestTriggerRollbackUseCase_Rerun_DryRunUnknownRun(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetWorkflowRunFunc = func(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+		return nil, errors.New("404 Not Found")
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	_, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "rerun",
+		RunID:      42,
+		DryRun:     true,
+	})
+
+	if err == nil {
+		t.Fatal("Execute() error = nil, want error for missing run")
+	}
+}
+
+func TestTriggerRollbackUseCase_Workflow_DryRunResolvesWorkflow(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetRepositoryFunc = func(ctx context.Context, owner, repo string) (*entity.Repository, error) {
+		return &entity.Repository{DefaultBranch: "trunk"}, nil
+	}
+	mockClient.GetWorkflowFunc = func(ctx context.Context, owner, repo, workflowID string) (*entity.Workflow, error) {
+		return &entity.Workflow{Name: "Rollback", Path: ".github/workflows/rollback.yml", State: "disabled_manually"}, nil
+	}
+	triggered := false
+	mockClient.TriggerWorkflowFunc = func(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error {
+		triggered = true
+		return nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "workflow",
+		WorkflowID: 77,
+		DryRun:     true,
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if triggered {
+		t.Error("TriggerWorkflow should not be called in dry run mode")
+	}
+	if result.Plan.Ref != "trunk" {
+		t.Errorf("Plan.Ref = %s, want trunk", result.Plan.Ref)
+	}
+	if len(result.Plan.Warnings) != 1 {
+		t.Errorf("Plan.Warnings = %v, want one warning for disabled workflow", result.Plan.Warnings)
+	}
+}
+
+func TestTriggerRollbackUseCase_Rerun_RefusesSuccessfulRun(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetWorkflowRunFunc = func(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+		return &entity.WorkflowRun{ID: runID, Status: "completed", Conclusion: "success"}, nil
+	}
+	rerunCalled := false
+	mockClient.RerunWorkflowFunc = func(ctx context.Context, owner, repo string, runID int64) error {
+		rerunCalled = true
+		return nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	_, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "rerun",
+		RunID:      10,
+	})
+
+	if !errors.Is(err, service.ErrRunSucceeded) {
+		t.Fatalf("Execute() error = %v, want %v", err, service.ErrRunSucceeded)
+	}
+	if rerunCalled {
+		t.Error("RerunWorkflow should not be called for a successful run")
+	}
+}
+
+func TestTriggerRollbackUseCase_BlockedWhileRollbackInFlight(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	rollbackService := service.NewRollbackService()
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), rollbackService, port.NewMockCacheInvalidator())
+
+	release, err := rollbackService.Begin("test/repo")
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	defer release()
+
+	_, err = uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "revert",
+		CommitSHA:  "abc123",
+	})
+
+	if !errors.Is(err, service.ErrRollbackInProgress) {
+		t.Fatalf("Execute() error = %v, want %v", err, service.ErrRollbackInProgress)
+	}
+	if len(mockClient.CreateBranchCalls) != 0 {
+		t.Error("CreateBranch should not be called while another rollback is in flight")
+	}
+}
+
+func TestTriggerRollbackUseCase_ReleasesRepositoryAfterRollback(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	rollbackService := service.NewRollbackService()
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), rollbackService, port.NewMockCacheInvalidator())
+
+	input := TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "rerun",
+		RunID:      10,
+	}
+
+	if _, err := uc.Execute(context.Background(), input); err != nil {
+		t.Fatalf("first Execute() error = %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), input); err != nil {
+		t.Fatalf("second Execute() error = %v, want repository released", err)
+	}
+}
+
+func TestTriggerRollbackUseCase_Auto_RevertsCulprit(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	var runsBranch string
+	mockClient.ListWorkflowRunsFunc = func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+		runsBranch = filter.Branch
+		return []entity.WorkflowRun{
+			{ID: 11, WorkflowID: 1, HeadSHA: "bad1234", Status: "completed", Conclusion: "failure"},
+			{ID: 10, WorkflowID: 1, HeadSHA: "good123", Status: "completed", Conclusion: "success"},
+		}, nil
+	}
+	mockClient.ListCommitsFunc = func(ctx context.Context, filter entity.CommitFilter) ([]entity.Commit, error) {
+		return []entity.Commit{
+			{SHA: "bad1234", Parents: []string{"good123"}},
+			{SHA: "good123", Parents: []string{"older12"}},
+		}, nil
+	}
+	mockClient.GetCommitFunc = func(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+		return &entity.Commit{SHA: sha, Message: "feat: oops", Parents: []string{"good123"}}, nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "custom/repo",
+		Strategy:   "auto",
+		DryRun:     true,
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if runsBranch != "production" {
+		t.Errorf("runs branch = %s, want production", runsBranch)
+	}
+	if result.Strategy != entity.RollbackRevert {
+		t.Errorf("Strategy = %v, want %v", result.Strategy, entity.RollbackRevert)
+	}
+	if result.Decision == nil || result.Decision.CommitSHA != "bad1234" {
+		t.Fatalf("Decision = %+v, want revert of bad1234", result.Decision)
+	}
+	if result.Plan == nil || result.Plan.Commit.SHA != "bad1234" {
+		t.Errorf("Plan = %+v, want commit bad1234", result.Plan)
+	}
+}
+
+func TestTriggerRollbackUseCase_Auto_NoStrategy(t *testing.T) {
+	// CI is green and there is no rollback workflow, so nothing says which
+	// commit to revert.
+	mockClient := port.NewMockGitHubClient()
+	mockClient.ListWorkflowRunsFunc = func(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+		return []entity.WorkflowRun{{ID: 10, HeadSHA: "head123", Status: "completed", Conclusion: "success"}}, nil
+	}
+	mockClient.ListCommitsFunc = func(ctx context.Context, filter entity.CommitFilter) ([]entity.Commit, error) {
+		return []entity.Commit{{SHA: "head123", Parents: []string{"prev123"}}}, nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	_, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "auto",
+	})
+
+	if !errors.Is(err, service.ErrNoStrategy) {
+		t.Fatalf("Execute() error = %v, want %v", err, service.ErrNoStrategy)
+	}
+	if len(mockClient.CreateBranchCalls) != 0 || len(mockClient.RevertCommitCalls) != 0 {
+		t.Errorf("created %d branches and %d reverts, want none", len(mockClient.CreateBranchCalls), len(mockClient.RevertCommitCalls))
+	}
+}
+
+func TestTriggerRollbackUseCase_Workflow_FromRepoConfig(t *testing.T) {
+	cfg := newRollbackTestConfig()
+	cfg.ReposConfig.Repositories["custom/repo"] = config.BranchConfig{
+		ProdBranch: "production",
+		DevBranch:  "staging",
+		Rollback: &config.RollbackConfig{
+			Workflow: "rollback.yml",
+			Ref:      "production",
+			Inputs:   map[string]string{"environment": "prod"},
+		},
+	}
+
+	var gotWorkflow, gotRef string
+	var gotInputs map[string]string
+
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetRepositoryFunc = func(ctx context.Context, owner, repo string) (*entity.Repository, error) {
+		t.Error("GetRepository should not be called when the ref is configured")
+		return &entity.Repository{DefaultBranch: "main"}, nil
+	}
+	mockClient.TriggerWorkflowFunc = func(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error {
+		gotWorkflow, gotRef, gotInputs = workflowID, ref, inputs
+		return nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, cfg, service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	result, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "custom/repo",
+		Strategy:   "workflow",
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success {
+		t.Error("Success = false, want true")
+	}
+	if gotWorkflow != "rollback.yml" {
+		t.Errorf("workflow = %s, want rollback.yml", gotWorkflow)
+	}
+	if gotRef != "production" {
+		t.Errorf("ref = %s, want production", gotRef)
+	}
+	if gotInputs["environment"] != "prod" {
+		t.Errorf("inputs = %v, want environment=prod", gotInputs)
+	}
+}
+
+func TestTriggerRollbackUseCase_Workflow_ExplicitIDOverridesConfig(t *testing.T) {
+	cfg := newRollbackTestConfig()
+	cfg.ReposConfig.Repositories["custom/repo"] = config.BranchConfig{
+		ProdBranch: "production",
+		DevBranch:  "staging",
+		Rollback: &config.RollbackConfig{
+			Workflow: "rollback.yml",
+			Ref:      "production",
+			Inputs:   map[string]string{"environment": "prod"},
+		},
+	}
+
+	var gotWorkflow, gotRef string
+	var gotInputs map[string]string
+
+	mockClient := port.NewMockGitHubClient()
+	mockClient.GetRepositoryFunc = func(ctx context.Context, owner, repo string) (*entity.Repository, error) {
+		return &entity.Repository{DefaultBranch: "main"}, nil
+	}
+	mockClient.TriggerWorkflowFunc = func(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error {
+		gotWorkflow, gotRef, gotInputs = workflowID, ref, inputs
+		return nil
+	}
+
+	uc := NewTriggerRollbackUseCase(mockClient, cfg, service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	_, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "custom/repo",
+		Strategy:   "workflow",
+		WorkflowID: 42,
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if gotWorkflow != "42" {
+		t.Errorf("workflow = %s, want 42", gotWorkflow)
+	}
+	if gotRef != "main" {
+		t.Errorf("ref = %s, want main (default branch)", gotRef)
+	}
+	if len(gotInputs) != 0 {
+		t.Errorf("inputs = %v, want none", gotInputs)
+	}
+}
+
+func TestTriggerRollbackUseCase_Workflow_MissingWorkflow(t *testing.T) {
+	mockClient := port.NewMockGitHubClient()
+	uc := NewTriggerRollbackUseCase(mockClient, newRollbackTestConfig(), service.NewRollbackService(), port.NewMockCacheInvalidator())
+
+	_, err := uc.Execute(context.Background(), TriggerRollbackInput{
+		Repository: "test/repo",
+		Strategy:   "workflow",
+	})
+
+	if !errors.Is(err, service.ErrMissingWorkflowID) {
+		t.Fatalf("Execute() error = %v, want %v", err, service.ErrMissingWorkflowID)
+	}
+}
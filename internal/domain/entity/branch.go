@@ -11,6 +11,10 @@ type BranchComparison struct {
 	GitHubStatus string // Raw status from GitHub API: "identical", "ahead", "behind", "diverged"
 	Commits      []Commit
 	Files        []ChangedFile
+	// Set when a provider limit cut a list short: FilesTruncated when Files is
+	// incomplete, CommitsTruncated when Commits is. TotalCommits stays exact.
+	FilesTruncated   bool
+	CommitsTruncated bool
 }
 
 type DriftStatus string
@@ -23,12 +27,13 @@ const (
 )
 
 type ChangedFile struct {
-	Filename  string
-	Status    string
-	Additions int
-	Deletions int
-	Changes   int
-	Patch     string
+	Filename         string
+	PreviousFilename string // Set when Status is "renamed"
+	Status           string
+	Additions        int
+	Deletions        int
+	Changes          int
+	Patch            string
 }
 
 type SyncPRRequest struct {
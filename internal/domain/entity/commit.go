@@ -13,6 +13,8 @@ type Commit struct {
 	Deletions   int
 	Repository  string
 	Branch      string
+	Parents     []string
+	Files       []ChangedFile
 }
 
 type CommitFilter struct {
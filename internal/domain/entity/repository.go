@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type Repository struct {
 	ID          int64
@@ -24,7 +27,47 @@ type Repository struct {
 type RepositoryStatus struct {
 	Repository    Repository
 	OpenPRs       int
-	FailedCI      bool
+	OldestPRAt    time.Time   // Creation time of the oldest open PR
+	FailedCI      bool        // The latest run failed on a configured branch
+	BranchCI      []BranchCI  // Latest completed run on each configured branch
 	LastCommitAt  time.Time
 	HasDrift      bool
+	DriftSeverity string      // "none", "low", "medium" or "high"
+	HealthScore   int         // 0-100, higher is healthier
+	Errors        []RepoError // Lookups that failed; the other fields may be incomplete
+}
+
+// BranchCI is the CI state of one branch.
+type BranchCI struct {
+	Branch     string
+	Conclusion string // Empty when the branch has no completed runs
+	RunURL     string
+}
+
+// ErrorKind classifies why a lookup for a repository failed.
+type ErrorKind string
+
+const (
+	ErrorAuth          ErrorKind = "auth"
+	ErrorNotFound      ErrorKind = "not_found"
+	ErrorRateLimit     ErrorKind = "rate_limit"
+	ErrorMissingBranch ErrorKind = "missing_branch"
+	ErrorUnsupported   ErrorKind = "unsupported"
+	ErrorOther         ErrorKind = "other"
+)
+
+// RepoError records a failed lookup for a single repository.
+type RepoError struct {
+	Repository string
+	Operation  string // What was being fetched, e.g. "list pull requests"
+	Kind       ErrorKind
+	Err        error
+}
+
+func (e RepoError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", e.Repository, e.Operation, e.Err)
+}
+
+func (e RepoError) Unwrap() error {
+	return e.Err
 }
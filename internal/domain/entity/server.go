@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// ServerStatus describes the server's view of the GitHub API: who it is
+// authenticated as, how much rate limit is left, and how much it is
+// answering from cache.
+type ServerStatus struct {
+	User      string
+	UserError string // Why the authenticated user could not be fetched
+	RateLimit RateLimitStatus
+	Retries   RetryStats
+	Cache     CacheStats
+}
+
+type RateLimitStatus struct {
+	Remaining int
+	ResetAt   time.Time
+	Threshold int // Requests wait for the reset once Remaining drops to this
+}
+
+type RetryStats struct {
+	Retries   uint64 // Attempts made after a retryable failure
+	Recovered uint64 // Operations that succeeded after retrying
+	Exhausted uint64 // Operations that failed after all retries
+}
+
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Bytes     int64
+}
@@ -14,6 +14,7 @@ type WorkflowRun struct {
 	HTMLURL      string
 	RunNumber    int
 	RunAttempt   int
+	RunStartedAt time.Time
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	Repository   string
@@ -44,12 +45,14 @@ const (
 	RollbackRerun     RollbackStrategy = "rerun"
 	RollbackRevert    RollbackStrategy = "revert"
 	RollbackWorkflow  RollbackStrategy = "workflow"
+	RollbackAuto      RollbackStrategy = "auto"
 )
 
 type RollbackRequest struct {
 	Repository  string
 	Strategy    RollbackStrategy
 	WorkflowID  int64
+	Workflow    string // Configured workflow file, used when WorkflowID is 0
 	RunID       int64
 	CommitSHA   string
 	DryRun      bool
@@ -61,4 +64,31 @@ type RollbackResult struct {
 	Message    string
 	RunURL     string
 	PRURL      string
+	Branch     string
+	RunID      int64
+	Conclusion string            // Set when the run was awaited to completion
+	Duration   time.Duration     // Duration of the awaited run attempt
+	Plan       *RollbackPlan     // What the rollback acts on, resolved against the API
+	Decision   *StrategyDecision // Set when the strategy was chosen automatically
+}
+
+// StrategyDecision is the outcome of automatic strategy selection.
+type StrategyDecision struct {
+	Strategy  RollbackStrategy // Empty when no strategy can be recommended
+	RunID     int64            // rerun: the run to re-run
+	CommitSHA string           // revert: the commit to revert
+	Reasons   []string
+}
+
+// RollbackPlan describes the concrete targets of a rollback. Only the fields
+// relevant to the strategy are set.
+type RollbackPlan struct {
+	Run        *WorkflowRun      // rerun: the run that will be re-run
+	Workflow   *Workflow         // workflow: the workflow that will be dispatched
+	Ref        string            // workflow: the ref the workflow is dispatched on
+	Inputs     map[string]string // workflow: the workflow_dispatch inputs
+	Commit     *Commit           // revert: the commit being reverted, with its files
+	Branch     string            // revert: the branch holding the revert commit
+	BaseBranch string            // revert: the branch the revert PR targets
+	Warnings   []string
 }
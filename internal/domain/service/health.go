@@ -0,0 +1,91 @@
+package service
+
+import (
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+// Penalties subtracted from a perfect health score of 100.
+const (
+	healthFailedCIPenalty = 25 // Per configured branch whose latest run failed
+
+	healthDriftLowPenalty    = 5
+	healthDriftMediumPenalty = 15
+	healthDriftHighPenalty   = 25
+
+	healthPRWeekPenalty      = 5 // Oldest open PR older than a week
+	healthPRFortnightPenalty = 10
+	healthPRMonthPenalty     = 15
+
+	healthPushMonthPenalty    = 5 // No push for over a month
+	healthPushQuarterPenalty  = 10
+	healthPushHalfYearPenalty = 20
+
+	// Per failed lookup, so a repository whose data could not be fetched does
+	// not pass for a healthy one. Unsupported lookups are not counted.
+	healthLookupErrorPenalty = 20
+)
+
+type HealthScorer struct{}
+
+func NewHealthScorer() *HealthScorer {
+	return &HealthScorer{}
+}
+
+// Score rates a repository from 0 to 100, higher is healthier. It penalizes
+// failing CI on the configured branches, drift between them, stale open PRs,
+// time since the last push and lookups that failed.
+func (h *HealthScorer) Score(status entity.RepositoryStatus, now time.Time) int {
+	score := 100
+
+	for _, ci := range status.BranchCI {
+		if IsFailedConclusion(ci.Conclusion) {
+			score -= healthFailedCIPenalty
+		}
+	}
+
+	switch status.DriftSeverity {
+	case "low":
+		score -= healthDriftLowPenalty
+	case "medium":
+		score -= healthDriftMediumPenalty
+	case "high":
+		score -= healthDriftHighPenalty
+	}
+
+	if !status.OldestPRAt.IsZero() {
+		age := now.Sub(status.OldestPRAt)
+		switch {
+		case age > 30*24*time.Hour:
+			score -= healthPRMonthPenalty
+		case age > 14*24*time.Hour:
+			score -= healthPRFortnightPenalty
+		case age > 7*24*time.Hour:
+			score -= healthPRWeekPenalty
+		}
+	}
+
+	if !status.LastCommitAt.IsZero() {
+		idle := now.Sub(status.LastCommitAt)
+		switch {
+		case idle > 180*24*time.Hour:
+			score -= healthPushHalfYearPenalty
+		case idle > 90*24*time.Hour:
+			score -= healthPushQuarterPenalty
+		case idle > 30*24*time.Hour:
+			score -= healthPushMonthPenalty
+		}
+	}
+
+	for _, err := range status.Errors {
+		if err.Kind != entity.ErrorUnsupported {
+			score -= healthLookupErrorPenalty
+		}
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+func TestHealthScorer_Score(t *testing.T) {
+	scorer := NewHealthScorer()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name   string
+		status entity.RepositoryStatus
+		want   int
+	}{
+		{
+			name:   "healthy repository",
+			status: entity.RepositoryStatus{LastCommitAt: now.Add(-day)},
+			want:   100,
+		},
+		{
+			name: "failing CI on both branches",
+			status: entity.RepositoryStatus{
+				LastCommitAt: now.Add(-day),
+				BranchCI: []entity.BranchCI{
+					{Branch: "main", Conclusion: "failure"},
+					{Branch: "develop", Conclusion: "timed_out"},
+				},
+			},
+			want: 50,
+		},
+		{
+			name: "cancelled run is not a failure",
+			status: entity.RepositoryStatus{
+				LastCommitAt: now.Add(-day),
+				BranchCI:     []entity.BranchCI{{Branch: "main", Conclusion: "cancelled"}},
+			},
+			want: 100,
+		},
+		{
+			name:   "high drift",
+			status: entity.RepositoryStatus{LastCommitAt: now.Add(-day), DriftSeverity: "high"},
+			want:   75,
+		},
+		{
+			name:   "stale pull request",
+			status: entity.RepositoryStatus{LastCommitAt: now.Add(-day), OldestPRAt: now.Add(-20 * day)},
+			want:   90,
+		},
+		{
+			name:   "inactive for a quarter",
+			status: entity.RepositoryStatus{LastCommitAt: now.Add(-100 * day)},
+			want:   90,
+		},
+		{
+			name: "failed lookups are penalized",
+			status: entity.RepositoryStatus{
+				LastCommitAt: now.Add(-day),
+				Errors: []entity.RepoError{
+					{Operation: "list pull requests", Kind: entity.ErrorNotFound},
+					{Operation: "list workflow runs on main", Kind: entity.ErrorAuth},
+					{Operation: "list workflow runs on develop", Kind: entity.ErrorAuth},
+					{Operation: "compare branches", Kind: entity.ErrorAuth},
+				},
+			},
+			want: 20,
+		},
+		{
+			name: "unsupported lookups are not penalized",
+			status: entity.RepositoryStatus{
+				LastCommitAt: now.Add(-day),
+				Errors:       []entity.RepoError{{Operation: "list pull requests", Kind: entity.ErrorUnsupported}},
+			},
+			want: 100,
+		},
+		{
+			name: "score never drops below zero",
+			status: entity.RepositoryStatus{
+				LastCommitAt:  now.Add(-365 * day),
+				OldestPRAt:    now.Add(-60 * day),
+				DriftSeverity: "high",
+				BranchCI: []entity.BranchCI{
+					{Branch: "main", Conclusion: "failure"},
+					{Branch: "develop", Conclusion: "failure"},
+					{Branch: "release", Conclusion: "failure"},
+				},
+			},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.Score(tt.status, now)
+			if got != tt.want {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,28 +1,257 @@
 package service
 
-import "github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+import (
+	"errors"
+	"fmt"
+	"sync"
 
-type RollbackService struct{}
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+// Typed errors for rollback validation.
+var (
+	ErrUnknownStrategy    = errors.New("unknown rollback strategy")
+	ErrMissingWorkflowID  = errors.New("workflow_id or a configured rollback workflow is required for workflow strategy")
+	ErrMissingCommitSHA   = errors.New("commit_sha is required for revert strategy")
+	ErrRunInProgress      = errors.New("workflow run is still in progress")
+	ErrRunSucceeded       = errors.New("workflow run already succeeded")
+	ErrRollbackInProgress = errors.New("another rollback is in progress for this repository")
+	ErrNoStrategy         = errors.New("no rollback strategy could be selected automatically")
+)
+
+type RollbackService struct {
+	mu       sync.Mutex
+	inFlight map[string]bool
+}
 
 func NewRollbackService() *RollbackService {
-	return &RollbackService{}
+	return &RollbackService{
+		inFlight: make(map[string]bool),
+	}
 }
 
+// ValidateRequest checks that the request names a known strategy, carries the
+// fields that strategy needs, and does not overlap a running rollback.
 func (r *RollbackService) ValidateRequest(req entity.RollbackRequest) error {
+	switch req.Strategy {
+	case entity.RollbackRerun:
+		// run_id is optional, the latest run is used when omitted
+	case entity.RollbackWorkflow:
+		if req.WorkflowID == 0 && req.Workflow == "" {
+			return ErrMissingWorkflowID
+		}
+	case entity.RollbackRevert:
+		if req.CommitSHA == "" {
+			return ErrMissingCommitSHA
+		}
+	default:
+		return fmt.Errorf("%w: '%s', must be rerun, revert, or workflow", ErrUnknownStrategy, req.Strategy)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.inFlight[req.Repository] {
+		return fmt.Errorf("%w: %s", ErrRollbackInProgress, req.Repository)
+	}
+
 	return nil
 }
 
-func (r *RollbackService) DetermineStrategy(runs []entity.WorkflowRun) entity.RollbackStrategy {
-	if len(runs) == 0 {
-		return entity.RollbackRevert
+// ValidateRerunTarget refuses runs that a rerun cannot help: runs still in
+// progress and runs that already succeeded.
+func (r *RollbackService) ValidateRerunTarget(run entity.WorkflowRun) error {
+	if run.Status != "" && run.Status != "completed" {
+		return fmt.Errorf("%w: run %d is %s", ErrRunInProgress, run.ID, run.Status)
+	}
+	if run.Conclusion == "success" {
+		return fmt.Errorf("%w: run %d", ErrRunSucceeded, run.ID)
+	}
+	return nil
+}
+
+// Begin marks a rollback on repository as in flight. The returned release
+// func must be called once the rollback has finished.
+func (r *RollbackService) Begin(repository string) (release func(), err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.inFlight[repository] {
+		return nil, fmt.Errorf("%w: %s", ErrRollbackInProgress, repository)
+	}
+	r.inFlight[repository] = true
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.inFlight, repository)
+	}, nil
+}
+
+// DetermineStrategy picks a rollback strategy from the recent runs and commits
+// of the prod branch, both ordered most recent first.
+//
+// A failure that looks flaky is re-run. A failure that started at a specific
+// commit reverts that commit, against its first parent for a merge. When CI
+// does not point at a cause, the rollback workflow is used if the repository
+// has one. Otherwise no strategy is picked, since there is nothing to tell
+// which commit to revert.
+func (r *RollbackService) DetermineStrategy(runs []entity.WorkflowRun, commits []entity.Commit, hasRollbackWorkflow bool) entity.StrategyDecision {
+	var completed []entity.WorkflowRun
+	for _, run := range runs {
+		if run.Status == "" || run.Status == "completed" {
+			completed = append(completed, run)
+		}
+	}
+
+	if len(completed) == 0 {
+		return fallbackStrategy(hasRollbackWorkflow, "no completed workflow runs on the prod branch")
 	}
 
-	lastRun := runs[0]
-	if lastRun.Conclusion == "failure" {
-		return entity.RollbackRerun
+	latest := completed[0]
+	if !IsFailedConclusion(latest.Conclusion) {
+		return fallbackStrategy(hasRollbackWorkflow,
+			fmt.Sprintf("latest run %d (%s) concluded %s, CI does not point at a cause", latest.ID, latest.Name, latest.Conclusion))
 	}
 
-	return entity.RollbackRevert
+	failed := fmt.Sprintf("latest run %d (%s) failed at %.7s", latest.ID, latest.Name, latest.HeadSHA)
+
+	if reason := flakySignal(latest, completed); reason != "" {
+		return entity.StrategyDecision{
+			Strategy: entity.RollbackRerun,
+			RunID:    latest.ID,
+			Reasons:  []string{failed, reason, "re-running is the cheapest fix for a flaky failure"},
+		}
+	}
+
+	if culprit, lastGreen := firstFailingRun(latest, completed); culprit != nil {
+		reasons := []string{
+			failed,
+			fmt.Sprintf("%s started failing at %.7s (run %d), the previous run %d at %.7s passed",
+				latest.Name, culprit.HeadSHA, culprit.ID, lastGreen.ID, lastGreen.HeadSHA),
+		}
+
+		if commit := findCommit(commits, culprit.HeadSHA); commit != nil && len(commit.Parents) > 1 {
+			reasons = append(reasons, fmt.Sprintf("%.7s is a merge commit, reverting it against its first parent %.7s", culprit.HeadSHA, commit.Parents[0]))
+		}
+
+		return entity.StrategyDecision{
+			Strategy:  entity.RollbackRevert,
+			CommitSHA: culprit.HeadSHA,
+			Reasons:   append(reasons, fmt.Sprintf("reverting %.7s undoes the change that broke the build", culprit.HeadSHA)),
+		}
+	}
+
+	reasons := []string{failed, fmt.Sprintf("no passing run of %s in recent history to compare against", latest.Name)}
+	if hasRollbackWorkflow {
+		return entity.StrategyDecision{
+			Strategy: entity.RollbackWorkflow,
+			Reasons:  append(reasons, "using the rollback workflow"),
+		}
+	}
+	return entity.StrategyDecision{
+		Strategy: entity.RollbackRerun,
+		RunID:    latest.ID,
+		Reasons:  append(reasons, "re-running to rule out a transient failure"),
+	}
+}
+
+// fallbackStrategy is used when CI history does not identify a cause. It
+// leaves Strategy empty without a rollback workflow.
+func fallbackStrategy(hasRollbackWorkflow bool, reason string) entity.StrategyDecision {
+	reasons := []string{reason}
+
+	if hasRollbackWorkflow {
+		return entity.StrategyDecision{
+			Strategy: entity.RollbackWorkflow,
+			Reasons:  append(reasons, "using the rollback workflow"),
+		}
+	}
+
+	return entity.StrategyDecision{
+		Reasons: append(reasons, "no rollback workflow configured, pick a strategy or a commit to revert"),
+	}
+}
+
+// flakyWindow is how many runs of a workflow are inspected for flip-flopping.
+const flakyWindow = 6
+
+// flakySignal explains why a failed run looks flaky, or returns "".
+func flakySignal(failed entity.WorkflowRun, runs []entity.WorkflowRun) string {
+	switch failed.Conclusion {
+	case "timed_out", "startup_failure":
+		return fmt.Sprintf("the run ended with %s, which usually points at infrastructure rather than code", failed.Conclusion)
+	}
+
+	var history []entity.WorkflowRun
+	for _, run := range runs {
+		if run.WorkflowID != failed.WorkflowID {
+			continue
+		}
+		if run.ID != failed.ID && failed.HeadSHA != "" && run.HeadSHA == failed.HeadSHA && run.Conclusion == "success" {
+			return fmt.Sprintf("the same commit passed in run %d", run.ID)
+		}
+		history = append(history, run)
+	}
+
+	// Several pass/fail flips in a short window without a stable streak.
+	if len(history) > flakyWindow {
+		history = history[:flakyWindow]
+	}
+	flips := 0
+	for i := 1; i < len(history); i++ {
+		if IsFailedConclusion(history[i].Conclusion) != IsFailedConclusion(history[i-1].Conclusion) {
+			flips++
+		}
+	}
+	if flips >= 3 {
+		return fmt.Sprintf("%s flipped between pass and fail %d times in the last %d runs", failed.Name, flips, len(history))
+	}
+
+	return ""
+}
+
+// firstFailingRun walks back through the failed run's workflow history and
+// returns the oldest run of the current failure streak together with the
+// passing run before it. Both are nil when no passing run is found or the
+// streak started on the commit that last passed.
+func firstFailingRun(failed entity.WorkflowRun, runs []entity.WorkflowRun) (culprit, lastGreen *entity.WorkflowRun) {
+	for i := range runs {
+		run := &runs[i]
+		if run.WorkflowID != failed.WorkflowID {
+			continue
+		}
+		if IsFailedConclusion(run.Conclusion) {
+			culprit = run
+			continue
+		}
+		if run.Conclusion != "success" {
+			continue
+		}
+		if culprit == nil || culprit.HeadSHA == "" || culprit.HeadSHA == run.HeadSHA {
+			return nil, nil
+		}
+		return culprit, run
+	}
+	return nil, nil
+}
+
+// IsFailedConclusion reports whether a run conclusion counts as a CI failure.
+func IsFailedConclusion(conclusion string) bool {
+	switch conclusion {
+	case "failure", "timed_out", "startup_failure":
+		return true
+	default:
+		return false
+	}
+}
+
+func findCommit(commits []entity.Commit, sha string) *entity.Commit {
+	for i := range commits {
+		if commits[i].SHA == sha {
+			return &commits[i]
+		}
+	}
+	return nil
 }
 
 func (r *RollbackService) GetRecommendedActions(status entity.DriftStatus) []string {
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -10,52 +11,157 @@ import (
 func TestRollbackService_DetermineStrategy(t *testing.T) {
 	service := NewRollbackService()
 
+	headCommits := []entity.Commit{
+		{SHA: "c3", Parents: []string{"c2"}},
+		{SHA: "c2", Parents: []string{"c1"}},
+		{SHA: "c1", Parents: []string{"c0"}},
+	}
+
 	tests := []struct {
-		name string
-		runs []entity.WorkflowRun
-		want entity.RollbackStrategy
+		name          string
+		runs          []entity.WorkflowRun
+		commits       []entity.Commit
+		hasWorkflow   bool
+		wantStrategy  entity.RollbackStrategy
+		wantRunID     int64
+		wantCommitSHA string
 	}{
 		{
-			name: "revert when no runs",
-			runs: []entity.WorkflowRun{},
-			want: entity.RollbackRevert,
+			name:         "no strategy when no runs and no rollback workflow",
+			runs:         []entity.WorkflowRun{},
+			commits:      headCommits,
+			wantStrategy: "",
+		},
+		{
+			name:         "workflow when no runs and a rollback workflow exists",
+			runs:         []entity.WorkflowRun{},
+			commits:      headCommits,
+			hasWorkflow:  true,
+			wantStrategy: entity.RollbackWorkflow,
+		},
+		{
+			name:         "no strategy when no runs and no commits",
+			wantStrategy: "",
+		},
+		{
+			name: "rerun when a single failure has no history",
+			runs: []entity.WorkflowRun{
+				{ID: 10, WorkflowID: 1, HeadSHA: "c3", Status: "completed", Conclusion: "failure"},
+			},
+			commits:      headCommits,
+			wantStrategy: entity.RollbackRerun,
+			wantRunID:    10,
+		},
+		{
+			name: "no strategy when last run succeeded",
+			runs: []entity.WorkflowRun{
+				{ID: 10, WorkflowID: 1, HeadSHA: "c3", Status: "completed", Conclusion: "success"},
+			},
+			commits:      headCommits,
+			wantStrategy: "",
+		},
+		{
+			name: "cancelled run does not count as failure",
+			runs: []entity.WorkflowRun{
+				{ID: 10, WorkflowID: 1, HeadSHA: "c3", Status: "completed", Conclusion: "cancelled"},
+			},
+			commits:      headCommits,
+			wantStrategy: "",
+		},
+		{
+			name: "in progress runs are ignored",
+			runs: []entity.WorkflowRun{
+				{ID: 11, WorkflowID: 1, HeadSHA: "c3", Status: "in_progress"},
+				{ID: 10, WorkflowID: 1, HeadSHA: "c2", Status: "completed", Conclusion: "failure"},
+			},
+			commits:      headCommits,
+			wantStrategy: entity.RollbackRerun,
+			wantRunID:    10,
+		},
+		{
+			name: "rerun when the same commit passed before (flaky)",
+			runs: []entity.WorkflowRun{
+				{ID: 11, WorkflowID: 1, HeadSHA: "c3", Status: "completed", Conclusion: "failure"},
+				{ID: 10, WorkflowID: 1, HeadSHA: "c3", Status: "completed", Conclusion: "success"},
+			},
+			commits:      headCommits,
+			wantStrategy: entity.RollbackRerun,
+			wantRunID:    11,
+		},
+		{
+			name: "rerun on timeout (flaky)",
+			runs: []entity.WorkflowRun{
+				{ID: 11, WorkflowID: 1, HeadSHA: "c3", Status: "completed", Conclusion: "timed_out"},
+				{ID: 10, WorkflowID: 1, HeadSHA: "c2", Status: "completed", Conclusion: "success"},
+			},
+			commits:      headCommits,
+			wantStrategy: entity.RollbackRerun,
+			wantRunID:    11,
 		},
 		{
-			name: "rerun when last run failed",
+			name: "rerun when workflow flips between pass and fail (flaky)",
 			runs: []entity.WorkflowRun{
-				{Conclusion: "failure"},
+				{ID: 14, WorkflowID: 1, HeadSHA: "c3", Status: "completed", Conclusion: "failure"},
+				{ID: 13, WorkflowID: 1, HeadSHA: "c2", Status: "completed", Conclusion: "success"},
+				{ID: 12, WorkflowID: 1, HeadSHA: "c1", Status: "completed", Conclusion: "failure"},
+				{ID: 11, WorkflowID: 1, HeadSHA: "c0", Status: "completed", Conclusion: "success"},
 			},
-			want: entity.RollbackRerun,
+			commits:      headCommits,
+			wantStrategy: entity.RollbackRerun,
+			wantRunID:    14,
 		},
 		{
-			name: "revert when last run succeeded",
+			name: "revert the commit where failures started",
 			runs: []entity.WorkflowRun{
-				{Conclusion: "success"},
+				{ID: 13, WorkflowID: 1, HeadSHA: "c3", Status: "completed", Conclusion: "failure"},
+				{ID: 12, WorkflowID: 2, HeadSHA: "c3", Status: "completed", Conclusion: "success"},
+				{ID: 11, WorkflowID: 1, HeadSHA: "c2", Status: "completed", Conclusion: "failure"},
+				{ID: 10, WorkflowID: 1, HeadSHA: "c1", Status: "completed", Conclusion: "success"},
 			},
-			want: entity.RollbackRevert,
+			commits:       headCommits,
+			wantStrategy:  entity.RollbackRevert,
+			wantCommitSHA: "c2",
 		},
 		{
-			name: "revert when last run cancelled",
+			name: "revert when failures started at a merge commit",
 			runs: []entity.WorkflowRun{
-				{Conclusion: "cancelled"},
+				{ID: 11, WorkflowID: 1, HeadSHA: "m1", Status: "completed", Conclusion: "failure"},
+				{ID: 10, WorkflowID: 1, HeadSHA: "c1", Status: "completed", Conclusion: "success"},
+			},
+			commits: []entity.Commit{
+				{SHA: "m1", Parents: []string{"c1", "f1"}},
+				{SHA: "c1", Parents: []string{"c0"}},
 			},
-			want: entity.RollbackRevert,
+			hasWorkflow:   true,
+			wantStrategy:  entity.RollbackRevert,
+			wantCommitSHA: "m1",
 		},
 		{
-			name: "uses first run in list (most recent)",
+			name: "workflow when no passing run in history",
 			runs: []entity.WorkflowRun{
-				{Conclusion: "failure"},
-				{Conclusion: "success"},
+				{ID: 11, WorkflowID: 1, HeadSHA: "c3", Status: "completed", Conclusion: "failure"},
+				{ID: 10, WorkflowID: 1, HeadSHA: "c2", Status: "completed", Conclusion: "failure"},
 			},
-			want: entity.RollbackRerun,
+			commits:      headCommits,
+			hasWorkflow:  true,
+			wantStrategy: entity.RollbackWorkflow,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := service.DetermineStrategy(tt.runs)
-			if got != tt.want {
-				t.Errorf("DetermineStrategy() = %v, want %v", got, tt.want)
+			got := service.DetermineStrategy(tt.runs, tt.commits, tt.hasWorkflow)
+			if got.Strategy != tt.wantStrategy {
+				t.Errorf("DetermineStrategy() strategy = %v, want %v (reasons: %v)", got.Strategy, tt.wantStrategy, got.Reasons)
+			}
+			if got.RunID != tt.wantRunID {
+				t.Errorf("DetermineStrategy() run = %d, want %d", got.RunID, tt.wantRunID)
+			}
+			if got.CommitSHA != tt.wantCommitSHA {
+				t.Errorf("DetermineStrategy() commit = %s, want %s", got.CommitSHA, tt.wantCommitSHA)
+			}
+			if len(got.Reasons) == 0 {
+				t.Error("DetermineStrategy() returned no reasons")
 			}
 		})
 	}
@@ -119,9 +225,122 @@ func TestRollbackService_GetRecommendedActions(t *testing.T) {
 func TestRollbackService_ValidateRequest(t *testing.T) {
 	service := NewRollbackService()
 
-	// Current implementation always returns nil
-	err := service.ValidateRequest(entity.RollbackRequest{})
+	tests := []struct {
+		name    string
+		req     entity.RollbackRequest
+		wantErr error
+	}{
+		{
+			name: "rerun without run id is valid",
+			req:  entity.RollbackRequest{Repository: "org/repo", Strategy: entity.RollbackRerun},
+		},
+		{
+			name: "workflow with workflow id is valid",
+			req:  entity.RollbackRequest{Repository: "org/repo", Strategy: entity.RollbackWorkflow, WorkflowID: 42},
+		},
+		{
+			name: "revert with commit sha is valid",
+			req:  entity.RollbackRequest{Repository: "org/repo", Strategy: entity.RollbackRevert, CommitSHA: "abc123"},
+		},
+		{
+			name:    "unknown strategy",
+			req:     entity.RollbackRequest{Repository: "org/repo", Strategy: "reset"},
+			wantErr: ErrUnknownStrategy,
+		},
+		{
+			name:    "empty strategy",
+			req:     entity.RollbackRequest{Repository: "org/repo"},
+			wantErr: ErrUnknownStrategy,
+		},
+		{
+			name:    "workflow without workflow id",
+			req:     entity.RollbackRequest{Repository: "org/repo", Strategy: entity.RollbackWorkflow},
+			wantErr: ErrMissingWorkflowID,
+		},
+		{
+			name:    "revert without commit sha",
+			req:     entity.RollbackRequest{Repository: "org/repo", Strategy: entity.RollbackRevert},
+			wantErr: ErrMissingCommitSHA,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ValidateRequest(tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateRequest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRollbackService_ValidateRerunTarget(t *testing.T) {
+	service := NewRollbackService()
+
+	tests := []struct {
+		name    string
+		run     entity.WorkflowRun
+		wantErr error
+	}{
+		{
+			name: "failed run can be rerun",
+			run:  entity.WorkflowRun{ID: 1, Status: "completed", Conclusion: "failure"},
+		},
+		{
+			name: "cancelled run can be rerun",
+			run:  entity.WorkflowRun{ID: 1, Status: "completed", Conclusion: "cancelled"},
+		},
+		{
+			name:    "in progress run is refused",
+			run:     entity.WorkflowRun{ID: 1, Status: "in_progress"},
+			wantErr: ErrRunInProgress,
+		},
+		{
+			name:    "queued run is refused",
+			run:     entity.WorkflowRun{ID: 1, Status: "queued"},
+			wantErr: ErrRunInProgress,
+		},
+		{
+			name:    "successful run is refused",
+			run:     entity.WorkflowRun{ID: 1, Status: "completed", Conclusion: "success"},
+			wantErr: ErrRunSucceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ValidateRerunTarget(tt.run)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateRerunTarget() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRollbackService_BlocksConcurrentRollbacks(t *testing.T) {
+	service := NewRollbackService()
+	req := entity.RollbackRequest{Repository: "org/repo", Strategy: entity.RollbackRerun}
+
+	release, err := service.Begin("org/repo")
 	if err != nil {
-		t.Errorf("ValidateRequest() error = %v, want nil", err)
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	if err := service.ValidateRequest(req); !errors.Is(err, ErrRollbackInProgress) {
+		t.Errorf("ValidateRequest() error = %v, want %v", err, ErrRollbackInProgress)
+	}
+	if _, err := service.Begin("org/repo"); !errors.Is(err, ErrRollbackInProgress) {
+		t.Errorf("Begin() error = %v, want %v", err, ErrRollbackInProgress)
+	}
+
+	// Other repositories are not blocked
+	if err := service.ValidateRequest(entity.RollbackRequest{Repository: "org/other", Strategy: entity.RollbackRerun}); err != nil {
+		t.Errorf("ValidateRequest() for other repo error = %v, want nil", err)
+	}
+
+	release()
+
+	if err := service.ValidateRequest(req); err != nil {
+		t.Errorf("ValidateRequest() after release error = %v, want nil", err)
 	}
 }
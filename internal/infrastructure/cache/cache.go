@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"container/list"
+	"strings"
 	"sync"
 	"time"
 )
@@ -8,27 +10,75 @@ import (
 // Entry represents a cached item with expiration.
 type Entry struct {
 	Value     any
+	StoredAt  time.Time
 	ExpiresAt time.Time
 }
 
+// Age returns how long ago the entry was stored.
+func (e *Entry) Age() time.Duration {
+	return time.Since(e.StoredAt)
+}
+
 // IsExpired checks if the entry has expired.
 func (e *Entry) IsExpired() bool {
 	return time.Now().After(e.ExpiresAt)
 }
 
-// Cache is a thread-safe in-memory cache with TTL support.
+// Store is the interface shared by the cache backends.
+type Store interface {
+	Get(key string) (any, bool)
+	GetEntry(key string) (Entry, bool)
+	Set(key string, value any)
+	SetWithTTL(key string, value any, ttl time.Duration)
+	Delete(key string)
+	DeletePrefix(prefix string) int
+	Clear()
+	Size() int
+	Keys() []string
+	Stats() Stats
+	Stop()
+}
+
+// Stats reports cache activity since the cache was created.
+type Stats struct {
+	Hits      uint64 // Lookups answered with a fresh entry
+	Misses    uint64 // Lookups that found no entry or an expired one
+	Evictions uint64 // Entries removed to stay within the size limits
+	Entries   int    // Current number of entries
+	Bytes     int64  // Current approximate size of the entries
+}
+
+// Cache is a thread-safe in-memory cache with TTL support. When full, it
+// evicts the least recently used entries.
 type Cache struct {
-	mu          sync.RWMutex
-	entries     map[string]*Entry
-	defaultTTL  time.Duration
-	cleanupTick time.Duration
-	stopCleanup chan struct{}
+	mu             sync.Mutex
+	entries        map[string]*list.Element // Values are *lruItem
+	lru            *list.List               // Most recently used at the front
+	totalBytes     int64
+	stats          Stats
+	defaultTTL     time.Duration
+	prefixTTLs     map[string]time.Duration
+	cleanupTick    time.Duration
+	staleRetention time.Duration
+	maxEntries     int
+	maxBytes       int64
+	stopCleanup    chan struct{}
+}
+
+type lruItem struct {
+	key   string
+	entry Entry
+	size  int64
 }
 
 // Config holds cache configuration.
 type Config struct {
-	DefaultTTL  time.Duration // Default time-to-live for entries
-	CleanupTick time.Duration // How often to run cleanup
+	DefaultTTL     time.Duration            // Default time-to-live for entries
+	PrefixTTLs     map[string]time.Duration // Time-to-live for keys starting with a prefix; the longest match wins
+	CleanupTick    time.Duration            // How often to run cleanup
+	StaleRetention time.Duration            // How long expired entries stay readable through GetEntry
+	MaxEntries     int                      // Entry count cap; 0 means no limit
+	MaxBytes       int64                    // Approximate size cap; 0 means no limit
 }
 
 // DefaultConfig returns sensible cache defaults.
@@ -36,16 +86,35 @@ func DefaultConfig() Config {
 	return Config{
 		DefaultTTL:  5 * time.Minute,
 		CleanupTick: 1 * time.Minute,
+		MaxEntries:  1000,
+		MaxBytes:    32 << 20,
+	}
+}
+
+// ttlFor returns the TTL of the longest prefix in ttls that key starts with,
+// or def when none matches.
+func ttlFor(ttls map[string]time.Duration, key string, def time.Duration) time.Duration {
+	ttl, matched := def, -1
+	for prefix, prefixTTL := range ttls {
+		if len(prefix) > matched && strings.HasPrefix(key, prefix) {
+			ttl, matched = prefixTTL, len(prefix)
+		}
 	}
+	return ttl
 }
 
 // New creates a new cache with the given configuration.
 func New(cfg Config) *Cache {
 	c := &Cache{
-		entries:     make(map[string]*Entry),
-		defaultTTL:  cfg.DefaultTTL,
-		cleanupTick: cfg.CleanupTick,
-		stopCleanup: make(chan struct{}),
+		entries:        make(map[string]*list.Element),
+		lru:            list.New(),
+		defaultTTL:     cfg.DefaultTTL,
+		prefixTTLs:     cfg.PrefixTTLs,
+		cleanupTick:    cfg.CleanupTick,
+		staleRetention: cfg.StaleRetention,
+		maxEntries:     cfg.MaxEntries,
+		maxBytes:       cfg.MaxBytes,
+		stopCleanup:    make(chan struct{}),
 	}
 
 	go c.startCleanup()
@@ -68,14 +137,15 @@ func (c *Cache) startCleanup() {
 	}
 }
 
-// cleanup removes all expired entries.
+// cleanup removes all entries expired for longer than the stale retention.
 func (c *Cache) cleanup() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for key, entry := range c.entries {
-		if entry.IsExpired() {
-			delete(c.entries, key)
+	cutoff := time.Now().Add(-c.staleRetention)
+	for _, elem := range c.entries {
+		if elem.Value.(*lruItem).entry.ExpiresAt.Before(cutoff) {
+			c.remove(elem)
 		}
 	}
 }
@@ -85,38 +155,92 @@ func (c *Cache) Stop() {
 	close(c.stopCleanup)
 }
 
-// Set stores a value with the default TTL.
+// Set stores a value with the TTL configured for the key's prefix, or the
+// default TTL.
 func (c *Cache) Set(key string, value any) {
-	c.SetWithTTL(key, value, c.defaultTTL)
+	c.SetWithTTL(key, value, ttlFor(c.prefixTTLs, key, c.defaultTTL))
 }
 
 // SetWithTTL stores a value with a custom TTL.
 func (c *Cache) SetWithTTL(key string, value any, ttl time.Duration) {
+	now := time.Now()
+	item := &lruItem{
+		key: key,
+		entry: Entry{
+			Value:     value,
+			StoredAt:  now,
+			ExpiresAt: now.Add(ttl),
+		},
+		size: int64(len(key)) + sizeOf(value),
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.entries[key] = &Entry{
-		Value:     value,
-		ExpiresAt: time.Now().Add(ttl),
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
 	}
+	c.entries[key] = c.lru.PushFront(item)
+	c.totalBytes += item.size
+
+	c.evict()
 }
 
 // Get retrieves a value from the cache.
 // Returns the value and true if found and not expired, nil and false otherwise.
 func (c *Cache) Get(key string) (any, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	entry, ok := c.GetEntry(key)
+	if !ok || entry.IsExpired() {
+		return nil, false
+	}
+	return entry.Value, true
+}
 
-	entry, ok := c.entries[key]
+// GetEntry retrieves an entry even if it has expired, as long as it has not
+// been cleaned up yet. Callers check Entry.IsExpired to tell stale from fresh.
+func (c *Cache) GetEntry(key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
 	if !ok {
-		return nil, false
+		c.stats.Misses++
+		return Entry{}, false
 	}
 
-	if entry.IsExpired() {
-		return nil, false
+	item := elem.Value.(*lruItem)
+	if item.entry.IsExpired() {
+		c.stats.Misses++
+	} else {
+		c.stats.Hits++
 	}
+	c.lru.MoveToFront(elem)
 
-	return entry.Value, true
+	return item.entry, true
+}
+
+// remove deletes an entry. Callers hold c.mu.
+func (c *Cache) remove(elem *list.Element) {
+	item := elem.Value.(*lruItem)
+	c.lru.Remove(elem)
+	delete(c.entries, item.key)
+	c.totalBytes -= item.size
+}
+
+// evict removes the least recently used entries until the cache fits in
+// maxEntries and maxBytes. The newest entry is kept even if it alone exceeds
+// maxBytes. Callers hold c.mu.
+func (c *Cache) evict() {
+	for c.lru.Len() > 1 {
+		overCount := c.maxEntries > 0 && c.lru.Len() > c.maxEntries
+		overBytes := c.maxBytes > 0 && c.totalBytes > c.maxBytes
+		if !overCount && !overBytes {
+			return
+		}
+
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
 }
 
 // Delete removes an entry from the cache.
@@ -124,7 +248,26 @@ func (c *Cache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	delete(c.entries, key)
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// DeletePrefix removes every entry whose key starts with prefix and returns
+// how many were removed.
+func (c *Cache) DeletePrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key, elem := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(elem)
+			removed++
+		}
+	}
+
+	return removed
 }
 
 // Clear removes all entries from the cache.
@@ -132,28 +275,41 @@ func (c *Cache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.entries = make(map[string]*Entry)
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	c.totalBytes = 0
 }
 
 // Size returns the number of entries in the cache.
 func (c *Cache) Size() int {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	return len(c.entries)
 }
 
 // Keys returns all non-expired keys in the cache.
 func (c *Cache) Keys() []string {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	keys := make([]string, 0, len(c.entries))
-	for key, entry := range c.entries {
-		if !entry.IsExpired() {
+	for key, elem := range c.entries {
+		if !elem.Value.(*lruItem).entry.IsExpired() {
 			keys = append(keys, key)
 		}
 	}
 
 	return keys
 }
+
+// Stats returns the cache counters and current size.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	stats.Bytes = c.totalBytes
+	return stats
+}
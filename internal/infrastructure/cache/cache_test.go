@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestCache(cfg Config) *Cache {
+	if cfg.DefaultTTL == 0 {
+		cfg.DefaultTTL = time.Minute
+	}
+	cfg.CleanupTick = time.Hour
+	return New(cfg)
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newTestCache(Config{MaxEntries: 2})
+	defer c.Stop()
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // b is now the least recently used
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) found, want evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("Get(%s) missing, want kept", key)
+		}
+	}
+	if got := c.Stats().Evictions; got != 1 {
+		t.Errorf("Evictions = %d, want 1", got)
+	}
+}
+
+func TestCache_EvictsOverByteBudget(t *testing.T) {
+	value := strings.Repeat("x", 1000)
+	c := newTestCache(Config{MaxBytes: 2500})
+	defer c.Stop()
+
+	for _, key := range []string{"a", "b", "c"} {
+		c.Set(key, value)
+	}
+
+	stats := c.Stats()
+	if stats.Entries != 2 {
+		t.Errorf("Entries = %d, want 2", stats.Entries)
+	}
+	if stats.Bytes > 2500 {
+		t.Errorf("Bytes = %d, want at most 2500", stats.Bytes)
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) found, want evicted")
+	}
+}
+
+func TestCache_KeepsSingleOversizedEntry(t *testing.T) {
+	c := newTestCache(Config{MaxBytes: 10})
+	defer c.Stop()
+
+	c.Set("big", strings.Repeat("x", 100))
+
+	if _, ok := c.Get("big"); !ok {
+		t.Error("Get(big) missing, want the newest entry kept")
+	}
+}
+
+func TestCache_PrefixTTLs(t *testing.T) {
+	c := newTestCache(Config{
+		DefaultTTL: time.Hour,
+		PrefixTTLs: map[string]time.Duration{
+			"prs:":      time.Minute,
+			"prs:acme/": -time.Second,
+		},
+	})
+	defer c.Stop()
+
+	c.Set("repos:all", 1)
+	c.Set("prs:other/api:open", 2)
+	c.Set("prs:acme/api:open", 3)
+
+	tests := []struct {
+		key     string
+		wantTTL time.Duration
+	}{
+		{"repos:all", time.Hour},
+		{"prs:other/api:open", time.Minute},
+		{"prs:acme/api:open", -time.Second},
+	}
+	for _, tt := range tests {
+		entry, ok := c.GetEntry(tt.key)
+		if !ok {
+			t.Fatalf("GetEntry(%s) missing", tt.key)
+		}
+		ttl := time.Until(entry.ExpiresAt)
+		if diff := ttl - tt.wantTTL; diff > time.Second || diff < -time.Second {
+			t.Errorf("TTL(%s) = %v, want %v", tt.key, ttl, tt.wantTTL)
+		}
+	}
+}
+
+func TestCache_Stats(t *testing.T) {
+	c := newTestCache(Config{})
+	defer c.Stop()
+
+	c.Set("a", "value")
+	c.SetWithTTL("expired", "value", -time.Second)
+
+	c.Get("a")
+	c.Get("a")
+	c.Get("missing")
+	c.Get("expired")
+
+	stats := c.Stats()
+	if stats.Hits != 2 {
+		t.Errorf("Hits = %d, want 2", stats.Hits)
+	}
+	if stats.Misses != 2 {
+		t.Errorf("Misses = %d, want 2", stats.Misses)
+	}
+	if stats.Entries != 2 {
+		t.Errorf("Entries = %d, want 2", stats.Entries)
+	}
+	if stats.Bytes <= 0 {
+		t.Errorf("Bytes = %d, want > 0", stats.Bytes)
+	}
+
+	c.Delete("a")
+	c.Clear()
+	if stats := c.Stats(); stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("after Clear: Entries = %d, Bytes = %d, want 0", stats.Entries, stats.Bytes)
+	}
+}
+
+func TestSizeOf(t *testing.T) {
+	type item struct {
+		Name string
+		Tags []string
+	}
+
+	small := sizeOf([]item{{Name: "a"}})
+	large := sizeOf([]item{{Name: strings.Repeat("a", 1000), Tags: []string{"x", "y"}}})
+
+	if large-small < 1000 {
+		t.Errorf("sizeOf grew by %d for a 1000 byte string, want at least 1000", large-small)
+	}
+	if got := sizeOf(nil); got != 0 {
+		t.Errorf("sizeOf(nil) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,492 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+const fileExt = ".gob"
+
+// FileConfig holds file cache configuration.
+type FileConfig struct {
+	Dir            string                   // Directory holding one file per entry
+	DefaultTTL     time.Duration            // Default time-to-live for entries
+	PrefixTTLs     map[string]time.Duration // Time-to-live for keys starting with a prefix; the longest match wins
+	CleanupTick    time.Duration            // How often to remove expired files
+	MaxBytes       int64                    // Size cap on disk; the entries closest to expiry are evicted first
+	StaleRetention time.Duration            // How long expired entries stay readable through GetEntry
+}
+
+// DefaultFileConfig returns file cache defaults, storing entries under
+// ~/.mcp-repo-monitor/cache.
+func DefaultFileConfig() FileConfig {
+	dir := filepath.Join(os.TempDir(), "mcp-repo-monitor-cache")
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		dir = filepath.Join(homeDir, ".mcp-repo-monitor", "cache")
+	}
+
+	return FileConfig{
+		Dir:         dir,
+		DefaultTTL:  5 * time.Minute,
+		CleanupTick: 1 * time.Minute,
+		MaxBytes:    50 << 20,
+	}
+}
+
+// Register makes a value type storable in the file cache. Values are gob
+// encoded behind an interface, so every concrete type must be registered.
+func Register(value any) {
+	gob.Register(value)
+}
+
+// fileHeader is encoded ahead of the value so the index can be rebuilt
+// without decoding every value.
+type fileHeader struct {
+	Key       string
+	StoredAt  time.Time
+	ExpiresAt time.Time
+}
+
+type fileRecord struct {
+	Value any
+}
+
+type fileMeta struct {
+	key       string
+	storedAt  time.Time
+	expiresAt time.Time
+	size      int64
+}
+
+// FileCache is a Store that keeps each entry in a gob file, so entries and
+// their expiry survive restarts. Unreadable files are treated as misses and
+// removed.
+type FileCache struct {
+	mu             sync.Mutex
+	dir            string
+	index          map[string]*fileMeta // Keyed by file name
+	totalBytes     int64
+	stats          Stats
+	writes         uint64 // Bumped by every change to the index, see GetEntry
+	defaultTTL     time.Duration
+	prefixTTLs     map[string]time.Duration
+	cleanupTick    time.Duration
+	staleRetention time.Duration
+	maxBytes       int64
+	logger         *logging.Logger
+	stopCleanup    chan struct{}
+}
+
+// NewFile creates a file cache in cfg.Dir, indexing the entries left by
+// previous runs.
+func NewFile(cfg FileConfig, logger *logging.Logger) (*FileCache, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	c := &FileCache{
+		dir:            cfg.Dir,
+		index:          make(map[string]*fileMeta),
+		defaultTTL:     cfg.DefaultTTL,
+		prefixTTLs:     cfg.PrefixTTLs,
+		cleanupTick:    cfg.CleanupTick,
+		staleRetention: cfg.StaleRetention,
+		maxBytes:       cfg.MaxBytes,
+		logger:         logger.WithComponent("file-cache"),
+		stopCleanup:    make(chan struct{}),
+	}
+
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	go c.startCleanup()
+
+	return c, nil
+}
+
+// load builds the index from the headers of the files on disk.
+func (c *FileCache) load() error {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	cutoff := time.Now().Add(-c.staleRetention)
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || !strings.HasSuffix(name, fileExt) {
+			continue
+		}
+
+		path := filepath.Join(c.dir, name)
+		header, size, err := readHeader(path)
+		if err != nil || header.ExpiresAt.Before(cutoff) {
+			if err != nil {
+				c.logger.Warn("removing unreadable cache file", "file", name, "error", err.Error())
+			}
+			os.Remove(path)
+			continue
+		}
+
+		c.index[name] = &fileMeta{key: header.Key, storedAt: header.StoredAt, expiresAt: header.ExpiresAt, size: size}
+		c.totalBytes += size
+	}
+
+	c.logger.Debug("file cache loaded", "dir", c.dir, "entries", len(c.index), "bytes", c.totalBytes)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evict()
+
+	return nil
+}
+
+func readHeader(path string) (fileHeader, int64, error) {
+	var header fileHeader
+
+	f, err := os.Open(path)
+	if err != nil {
+		return header, 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return header, 0, err
+	}
+
+	if err := gob.NewDecoder(f).Decode(&header); err != nil {
+		return header, 0, err
+	}
+
+	return header, info.Size(), nil
+}
+
+// fileName maps a key to a file name that is safe on every filesystem.
+func fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]) + fileExt
+}
+
+// startCleanup periodically removes expired entries.
+func (c *FileCache) startCleanup() {
+	ticker := time.NewTicker(c.cleanupTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.cleanup()
+		case <-c.stopCleanup:
+			return
+		}
+	}
+}
+
+// cleanup removes all entries expired for longer than the stale retention.
+func (c *FileCache) cleanup() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cutoff := time.Now().Add(-c.staleRetention)
+	for name, meta := range c.index {
+		if meta.expiresAt.Before(cutoff) {
+			c.remove(name)
+		}
+	}
+}
+
+// Stop stops the cleanup goroutine. Entries stay on disk.
+func (c *FileCache) Stop() {
+	close(c.stopCleanup)
+}
+
+// Set stores a value with the TTL configured for the key's prefix, or the
+// default TTL.
+func (c *FileCache) Set(key string, value any) {
+	c.SetWithTTL(key, value, ttlFor(c.prefixTTLs, key, c.defaultTTL))
+}
+
+// SetWithTTL stores a value with a custom TTL. Values that cannot be encoded
+// are logged and not cached.
+func (c *FileCache) SetWithTTL(key string, value any, ttl time.Duration) {
+	now := time.Now()
+	header := fileHeader{Key: key, StoredAt: now, ExpiresAt: now.Add(ttl)}
+
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(header); err != nil {
+		c.logger.Warn("failed to encode cache entry", "key", key, "error", err.Error())
+		return
+	}
+	if err := enc.Encode(fileRecord{Value: value}); err != nil {
+		c.logger.Warn("failed to encode cache entry", "key", key, "error", err.Error())
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := fileName(key)
+	if err := writeFileAtomic(filepath.Join(c.dir, name), buf.Bytes()); err != nil {
+		c.logger.Warn("failed to write cache entry", "key", key, "error", err.Error())
+		return
+	}
+
+	if old, ok := c.index[name]; ok {
+		c.totalBytes -= old.size
+	}
+	c.index[name] = &fileMeta{key: key, storedAt: header.StoredAt, expiresAt: header.ExpiresAt, size: int64(buf.Len())}
+	c.totalBytes += int64(buf.Len())
+	c.writes++
+
+	c.evict()
+}
+
+// writeFileAtomic writes through a temp file so readers never see a
+// partially written entry.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get retrieves a value from the cache.
+// Returns the value and true if found and not expired, nil and false otherwise.
+func (c *FileCache) Get(key string) (any, bool) {
+	entry, ok := c.GetEntry(key)
+	if !ok || entry.IsExpired() {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+// GetEntry retrieves an entry even if it has expired, as long as it has not
+// been cleaned up yet. Callers check Entry.IsExpired to tell stale from fresh.
+//
+// Other processes may share the directory, so an index miss falls back to
+// the disk and a file that has disappeared since it was indexed is a plain
+// miss.
+//
+// The file is read and decoded without holding c.mu, so concurrent lookups
+// of different keys do not wait on each other's disk reads. Files are
+// replaced atomically, so the read always sees a whole entry; if the index
+// changed meanwhile, the read is returned but not used to update the index.
+func (c *FileCache) GetEntry(key string) (Entry, bool) {
+	name := fileName(key)
+
+	c.mu.Lock()
+	meta, ok := c.index[name]
+	if ok && meta.expiresAt.Before(time.Now().Add(-c.staleRetention)) {
+		c.stats.Misses++
+		c.remove(name)
+		c.mu.Unlock()
+		return Entry{}, false
+	}
+	writes := c.writes
+	c.mu.Unlock()
+
+	header, value, size, err := c.read(name, key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current := c.writes == writes
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			c.logger.Warn("removing unreadable cache file", "key", key, "error", err.Error())
+		}
+		c.stats.Misses++
+		if current {
+			c.remove(name)
+		}
+		return Entry{}, false
+	}
+
+	if header.ExpiresAt.Before(time.Now().Add(-c.staleRetention)) {
+		c.stats.Misses++
+		if current {
+			c.remove(name)
+		}
+		return Entry{}, false
+	}
+
+	// The file may have been written by another process since it was
+	// indexed, so the index follows what was read.
+	if current {
+		if old, indexed := c.index[name]; indexed {
+			c.totalBytes -= old.size
+		}
+		c.index[name] = &fileMeta{key: key, storedAt: header.StoredAt, expiresAt: header.ExpiresAt, size: size}
+		c.totalBytes += size
+		c.writes++
+		if !ok {
+			c.evict()
+		}
+	}
+
+	entry := Entry{Value: value, StoredAt: header.StoredAt, ExpiresAt: header.ExpiresAt}
+	if entry.IsExpired() {
+		c.stats.Misses++
+	} else {
+		c.stats.Hits++
+	}
+
+	return entry, true
+}
+
+func (c *FileCache) read(name, key string) (fileHeader, any, int64, error) {
+	var header fileHeader
+
+	data, err := os.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		return header, nil, 0, err
+	}
+
+	dec := gob.NewDecoder(bytes.NewReader(data))
+
+	if err := dec.Decode(&header); err != nil {
+		return header, nil, 0, err
+	}
+	if header.Key != key {
+		return header, nil, 0, errors.New("cache file holds a different key")
+	}
+
+	var record fileRecord
+	if err := dec.Decode(&record); err != nil {
+		return header, nil, 0, err
+	}
+
+	return header, record.Value, int64(len(data)), nil
+}
+
+// remove deletes an entry's file and index entry. Callers hold c.mu.
+func (c *FileCache) remove(name string) {
+	if meta, ok := c.index[name]; ok {
+		c.totalBytes -= meta.size
+		delete(c.index, name)
+	}
+	c.writes++
+	os.Remove(filepath.Join(c.dir, name))
+}
+
+// evict removes the entries closest to expiry until the cache fits in
+// maxBytes. Callers hold c.mu.
+func (c *FileCache) evict() {
+	if c.maxBytes <= 0 || c.totalBytes <= c.maxBytes {
+		return
+	}
+
+	names := make([]string, 0, len(c.index))
+	for name := range c.index {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return c.index[names[i]].expiresAt.Before(c.index[names[j]].expiresAt)
+	})
+
+	for _, name := range names {
+		if c.totalBytes <= c.maxBytes {
+			break
+		}
+		c.logger.Debug("evicting cache entry", "key", c.index[name].key)
+		c.remove(name)
+		c.stats.Evictions++
+	}
+}
+
+// Delete removes an entry from the cache.
+func (c *FileCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(fileName(key))
+}
+
+// DeletePrefix removes every entry whose key starts with prefix and returns
+// how many were removed.
+func (c *FileCache) DeletePrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for name, meta := range c.index {
+		if strings.HasPrefix(meta.key, prefix) {
+			c.remove(name)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+// Clear removes all entries from the cache.
+func (c *FileCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name := range c.index {
+		c.remove(name)
+	}
+}
+
+// Size returns the number of entries in the cache.
+func (c *FileCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.index)
+}
+
+// Keys returns all non-expired keys in the cache.
+func (c *FileCache) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	keys := make([]string, 0, len(c.index))
+	for _, meta := range c.index {
+		if !now.After(meta.expiresAt) {
+			keys = append(keys, meta.key)
+		}
+	}
+
+	return keys
+}
+
+// Stats returns the cache counters and current size on disk.
+func (c *FileCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.index)
+	stats.Bytes = c.totalBytes
+	return stats
+}
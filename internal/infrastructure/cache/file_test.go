@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+func newTestFileCache(t *testing.T, cfg FileConfig, logger *logging.Logger) *FileCache {
+	t.Helper()
+
+	if cfg.DefaultTTL == 0 {
+		cfg.DefaultTTL = time.Minute
+	}
+	cfg.CleanupTick = time.Hour
+	if logger == nil {
+		logger = logging.NewNoop()
+	}
+
+	c, err := NewFile(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewFile: %v", err)
+	}
+	t.Cleanup(c.Stop)
+	return c
+}
+
+// newWarnLogger returns a logger that writes warnings to buf.
+func newWarnLogger(buf *bytes.Buffer) *logging.Logger {
+	return &logging.Logger{Logger: slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelWarn}))}
+}
+
+func TestFileCache_SurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	first := newTestFileCache(t, FileConfig{Dir: dir}, nil)
+	first.SetWithTTL("repos:acme", "cached", time.Hour)
+	stored, _ := first.GetEntry("repos:acme")
+
+	second := newTestFileCache(t, FileConfig{Dir: dir}, nil)
+
+	entry, ok := second.GetEntry("repos:acme")
+	if !ok || entry.Value != "cached" {
+		t.Fatalf("GetEntry(repos:acme) = %v, %v; want cached", entry.Value, ok)
+	}
+	if !entry.ExpiresAt.Equal(stored.ExpiresAt) {
+		t.Errorf("ExpiresAt = %v, want %v kept from before the restart", entry.ExpiresAt, stored.ExpiresAt)
+	}
+	if got := second.Size(); got != 1 {
+		t.Errorf("Size() = %d, want 1", got)
+	}
+}
+
+func TestFileCache_RecoversFromCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, fileName("prs:acme/api"))
+	if err := os.WriteFile(corrupt, []byte("not gob"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newTestFileCache(t, FileConfig{Dir: dir}, nil)
+	if _, err := os.Stat(corrupt); !os.IsNotExist(err) {
+		t.Errorf("corrupt file still on disk after load (stat error %v)", err)
+	}
+
+	c.Set("repos:acme", "cached")
+	if err := os.WriteFile(filepath.Join(dir, fileName("repos:acme")), []byte("truncated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("repos:acme"); ok {
+		t.Error("Get(repos:acme) found, want miss for a corrupt file")
+	}
+	if c.Size() != 0 {
+		t.Errorf("Size() = %d, want 0 after dropping the corrupt entry", c.Size())
+	}
+
+	c.Set("repos:acme", "fresh")
+	if value, ok := c.Get("repos:acme"); !ok || value != "fresh" {
+		t.Errorf("Get(repos:acme) = %v, %v; want fresh", value, ok)
+	}
+}
+
+func TestFileCache_EvictsClosestToExpiryOverCap(t *testing.T) {
+	value := strings.Repeat("x", 1000)
+	c := newTestFileCache(t, FileConfig{Dir: t.TempDir(), MaxBytes: 2500}, nil)
+
+	c.SetWithTTL("a", value, time.Hour)
+	c.SetWithTTL("b", value, time.Minute)
+	c.SetWithTTL("c", value, 2*time.Hour)
+
+	stats := c.Stats()
+	if stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("Entries = %d, Evictions = %d; want 2 and 1", stats.Entries, stats.Evictions)
+	}
+	if stats.Bytes > 2500 {
+		t.Errorf("Bytes = %d, want at most 2500", stats.Bytes)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) found, want evicted as closest to expiry")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("Get(%s) missing, want kept", key)
+		}
+	}
+}
+
+func TestFileCache_SharesDirectoryWithOtherProcess(t *testing.T) {
+	dir := t.TempDir()
+	var warnings bytes.Buffer
+
+	writer := newTestFileCache(t, FileConfig{Dir: dir}, nil)
+	reader := newTestFileCache(t, FileConfig{Dir: dir}, newWarnLogger(&warnings))
+
+	writer.Set("repos:acme", "from writer")
+	if value, ok := reader.Get("repos:acme"); !ok || value != "from writer" {
+		t.Fatalf("Get(repos:acme) = %v, %v; want the other cache's write", value, ok)
+	}
+
+	writer.Set("repos:acme", "updated")
+	if value, _ := reader.Get("repos:acme"); value != "updated" {
+		t.Errorf("Get(repos:acme) = %v, want updated", value)
+	}
+
+	writer.Delete("repos:acme")
+	if _, ok := reader.Get("repos:acme"); ok {
+		t.Error("Get(repos:acme) found, want miss once the other cache removed it")
+	}
+	if reader.Size() != 0 {
+		t.Errorf("Size() = %d, want the vanished entry dropped", reader.Size())
+	}
+	if warnings.Len() != 0 {
+		t.Errorf("logged %q, want a vanished file to be a silent miss", warnings.String())
+	}
+}
+
+func TestFileCache_ConcurrentAccessKeepsIndexConsistent(t *testing.T) {
+	dir := t.TempDir()
+	c := newTestFileCache(t, FileConfig{Dir: dir}, nil)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				key := fmt.Sprintf("prs:acme/repo-%d", (w+i)%4)
+				switch i % 3 {
+				case 0:
+					c.Set(key, strings.Repeat("x", i))
+				case 1:
+					c.Get(key)
+				case 2:
+					c.Delete(key)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"+fileExt))
+	var size int64
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size += info.Size()
+	}
+
+	stats := c.Stats()
+	if stats.Entries != len(files) || stats.Bytes != size {
+		t.Errorf("Entries = %d, Bytes = %d; want %d files of %d bytes on disk", stats.Entries, stats.Bytes, len(files), size)
+	}
+}
@@ -0,0 +1,78 @@
+package cache
+
+import "sync"
+
+// call is an in-flight or completed Group call.
+type call struct {
+	wg  sync.WaitGroup
+	val any
+	err error
+}
+
+// Group deduplicates concurrent work by key: while a call for a key is in
+// flight, later callers for the same key wait for it and share its result.
+// The zero value is ready to use.
+type Group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// Do runs fn for key unless a call for key is already in flight, in which case
+// it waits for that call. shared reports whether the result came from a call
+// started by someone else.
+func (g *Group) Do(key string, fn func() (any, error)) (v any, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err, true
+	}
+
+	c := g.start(key)
+	g.mu.Unlock()
+
+	g.run(key, c, fn)
+	return c.val, c.err, false
+}
+
+// Go runs fn for key in the background unless a call for key is already in
+// flight. It reports whether fn was started. Callers of Do for the same key
+// wait for the background call.
+func (g *Group) Go(key string, fn func() (any, error)) bool {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	if _, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		return false
+	}
+
+	c := g.start(key)
+	g.mu.Unlock()
+
+	go g.run(key, c, fn)
+	return true
+}
+
+// start registers a call for key. Callers hold g.mu.
+func (g *Group) start(key string) *call {
+	c := &call{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	return c
+}
+
+func (g *Group) run(key string, c *call, fn func() (any, error)) {
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.val, c.err = fn()
+}
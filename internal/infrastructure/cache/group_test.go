@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup_Do_SharesConcurrentCalls(t *testing.T) {
+	var g Group
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]any, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err, _ := g.Do("key", func() (any, error) {
+				calls.Add(1)
+				<-release
+				return "value", nil
+			})
+			if err != nil {
+				t.Errorf("Do() error = %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+
+	// Let every caller reach Do before the first call completes.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+	for i, v := range results {
+		if v != "value" {
+			t.Errorf("results[%d] = %v, want value", i, v)
+		}
+	}
+}
+
+func TestGroup_Do_RunsAgainAfterCompletion(t *testing.T) {
+	var g Group
+	var calls int
+
+	for i := 0; i < 2; i++ {
+		g.Do("key", func() (any, error) {
+			calls++
+			return nil, nil
+		})
+	}
+
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestGroup_Go_StartsOnce(t *testing.T) {
+	var g Group
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	fn := func() (any, error) {
+		calls.Add(1)
+		<-release
+		return "refreshed", nil
+	}
+
+	if !g.Go("key", fn) {
+		t.Fatal("first Go() = false, want true")
+	}
+	if g.Go("key", fn) {
+		t.Error("second Go() = true, want false while in flight")
+	}
+
+	done := make(chan any)
+	go func() {
+		v, _, shared := g.Do("key", fn)
+		if !shared {
+			t.Error("Do() shared = false, want true")
+		}
+		done <- v
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if v := <-done; v != "refreshed" {
+		t.Errorf("Do() = %v, want refreshed", v)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
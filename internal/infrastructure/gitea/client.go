@@ -0,0 +1,634 @@
+// Package gitea implements port.GitHubClient on the Gitea REST API (v1),
+// which Forgejo serves as well. Pull requests, commits, compare and branches
+// map closely onto GitHub's; Actions runs need Gitea 1.24 or later.
+//
+// Operations Gitea has no API for, such as re-running a workflow run or
+// reverting a commit, return a *port.UnsupportedError.
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// maxPerPage is the default page size limit of Gitea instances
+// (MAX_RESPONSE_ITEMS).
+const maxPerPage = 50
+
+// provider names Gitea in unsupported operation errors.
+const provider = "gitea"
+
+type Client struct {
+	baseURL string // API root, e.g. https://git.example.com/api/v1
+	token   string
+	http    *http.Client
+	logger  *logging.Logger
+}
+
+// NewClient creates a Gitea client for the instance at instanceURL, e.g.
+// https://git.example.com, authenticating with an access token.
+func NewClient(instanceURL, token string, logger *logging.Logger) (*Client, error) {
+	u, err := url.Parse(instanceURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid Gitea URL %q: expected an absolute http(s) URL", instanceURL)
+	}
+
+	clientLogger := logger.WithComponent("gitea")
+	clientLogger.Info("gitea client initialized", "url", u.String())
+
+	return &Client{
+		baseURL: strings.TrimSuffix(u.String(), "/") + "/api/v1",
+		token:   token,
+		http:    &http.Client{},
+		logger:  clientLogger,
+	}, nil
+}
+
+// APIError is an error response from the Gitea API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gitea: %d %s", e.StatusCode, e.Message)
+}
+
+// translateError wraps API errors with the matching port error.
+func translateError(err *APIError) error {
+	switch err.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %w", port.ErrUnauthorized, err)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %w", port.ErrNotFound, err)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %w", port.ErrRateLimited, err)
+	default:
+		return err
+	}
+}
+
+// repository returns the URL path of a repository.
+func repository(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+// do sends a request to the API and decodes the JSON response into out,
+// when not nil. It returns the response so callers can read pagination
+// headers; its body is already closed.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "token "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return resp, translateError(&APIError{StatusCode: resp.StatusCode, Message: errorMessage(resp.Body)})
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, fmt.Errorf("gitea: decode %s %s: %w", method, path, err)
+		}
+	}
+	return resp, nil
+}
+
+// errorMessage extracts the message of an error response.
+func errorMessage(body io.Reader) string {
+	var payload struct {
+		Message string `json:"message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(body, 64<<10))
+	if err := json.Unmarshal(data, &payload); err != nil || payload.Message == "" {
+		return strings.TrimSpace(string(data))
+	}
+	return payload.Message
+}
+
+// hasNextPage reports whether the Link header of resp points to a next page.
+func hasNextPage(resp *http.Response) bool {
+	for _, link := range strings.Split(resp.Header.Get("Link"), ",") {
+		if strings.Contains(link, `rel="next"`) {
+			return true
+		}
+	}
+	return false
+}
+
+// listPages fetches successive pages of path until the API links no next
+// page or limit items were collected. A limit of 0 collects every page.
+func listPages[T any](ctx context.Context, c *Client, path string, query url.Values, limit int) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	perPage := maxPerPage
+	if limit > 0 && limit < maxPerPage {
+		perPage = limit
+	}
+	query.Set("limit", strconv.Itoa(perPage))
+
+	var all []T
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var items []T
+		resp, err := c.do(ctx, http.MethodGet, path, query, nil, &items)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+		if limit > 0 && len(all) >= limit {
+			return all[:limit], nil
+		}
+		if len(items) == 0 || !hasNextPage(resp) {
+			return all, nil
+		}
+	}
+}
+
+func (c *Client) ListRepositories(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+	items, err := listPages[apiRepository](ctx, c, "/user/repos", nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []entity.Repository
+	for _, r := range items {
+		if !includeArchived && r.Archived {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(r.FullName), strings.ToLower(filter)) {
+			continue
+		}
+		repos = append(repos, toRepository(r))
+	}
+
+	c.logger.Debug("listed repositories", "count", len(repos))
+	return repos, nil
+}
+
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*entity.Repository, error) {
+	var r apiRepository
+	if _, err := c.do(ctx, http.MethodGet, repository(owner, repo), nil, nil, &r); err != nil {
+		return nil, err
+	}
+	result := toRepository(r)
+	return &result, nil
+}
+
+// ListPullRequests lists pull requests, most recently updated first. With
+// no repository set, it goes through the listed repositories like the
+// GitHub client does.
+func (c *Client) ListPullRequests(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+	limit := 30
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	if filter.Repository != "" {
+		owner, repo, ok := strings.Cut(filter.Repository, "/")
+		if !ok {
+			return nil, nil
+		}
+		return c.listPullRequests(ctx, owner, repo, filter.State, limit)
+	}
+
+	repos, err := c.ListRepositories(ctx, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []entity.PullRequest
+	for _, r := range repos {
+		owner, repo, ok := strings.Cut(r.FullName, "/")
+		if !ok {
+			continue
+		}
+		prs, err := c.listPullRequests(ctx, owner, repo, filter.State, limit)
+		if err != nil {
+			continue
+		}
+		all = append(all, prs...)
+		if len(all) >= limit {
+			return all[:limit], nil
+		}
+	}
+	return all, nil
+}
+
+func (c *Client) listPullRequests(ctx context.Context, owner, repo, state string, limit int) ([]entity.PullRequest, error) {
+	if state == "" {
+		state = "open"
+	}
+	query := url.Values{"state": {state}, "sort": {"recentupdate"}}
+
+	items, err := listPages[apiPullRequest](ctx, c, repository(owner, repo)+"/pulls", query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	prs := make([]entity.PullRequest, 0, len(items))
+	for _, pr := range items {
+		prs = append(prs, toPullRequest(pr, owner+"/"+repo))
+	}
+	return prs, nil
+}
+
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*entity.PullRequest, error) {
+	pr, err := c.getPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	result := toPullRequest(*pr, owner+"/"+repo)
+	return &result, nil
+}
+
+func (c *Client) getPullRequest(ctx context.Context, owner, repo string, number int) (*apiPullRequest, error) {
+	var pr apiPullRequest
+	if _, err := c.do(ctx, http.MethodGet, pullRequest(owner, repo, number), nil, nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+func pullRequest(owner, repo string, number int) string {
+	return repository(owner, repo) + "/pulls/" + strconv.Itoa(number)
+}
+
+// CreatePullRequest opens a pull request. Gitea marks drafts by a WIP title
+// prefix.
+func (c *Client) CreatePullRequest(ctx context.Context, owner, repo, title, body, head, base string, draft bool) (*entity.PullRequest, error) {
+	if draft {
+		title = "WIP: " + title
+	}
+
+	var pr apiPullRequest
+	_, err := c.do(ctx, http.MethodPost, repository(owner, repo)+"/pulls", nil, map[string]any{
+		"title": title,
+		"body":  body,
+		"head":  head,
+		"base":  base,
+	}, &pr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("created pull request",
+		"repo", owner+"/"+repo,
+		"number", pr.Number,
+		"head", head,
+		"base", base,
+	)
+
+	result := toPullRequest(pr, owner+"/"+repo)
+	return &result, nil
+}
+
+// MergePullRequest merges a pull request. The merge endpoint returns no
+// body, so the pull request is read back for the merge commit.
+func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, number int, method, commitTitle string) (*entity.MergeResult, error) {
+	if method == "" {
+		method = string(entity.MergeMethodMerge)
+	}
+
+	params := map[string]any{"Do": method}
+	if commitTitle != "" {
+		params["MergeTitleField"] = commitTitle
+	}
+	if _, err := c.do(ctx, http.MethodPost, pullRequest(owner, repo, number)+"/merge", nil, params, nil); err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("merged pull request",
+		"repo", owner+"/"+repo,
+		"number", number,
+		"method", method,
+	)
+
+	pr, err := c.getPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.MergeResult{
+		Success:     pr.Merged,
+		SHA:         pr.MergeCommitSHA,
+		Message:     "Pull request merged",
+		PRURL:       pr.HTMLURL,
+		PRNumber:    number,
+		MergeMethod: entity.MergeMethod(method),
+	}, nil
+}
+
+// ListCommits lists commits on a branch, or the default branch. With no
+// repository set, it goes through the listed repositories.
+func (c *Client) ListCommits(ctx context.Context, filter entity.CommitFilter) ([]entity.Commit, error) {
+	limit := 30
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	if filter.Repository != "" {
+		owner, repo, ok := strings.Cut(filter.Repository, "/")
+		if !ok {
+			return nil, nil
+		}
+		return c.listCommits(ctx, owner, repo, filter.Branch, filter.Since, limit)
+	}
+
+	repos, err := c.ListRepositories(ctx, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []entity.Commit
+	for _, r := range repos {
+		owner, repo, ok := strings.Cut(r.FullName, "/")
+		if !ok {
+			continue
+		}
+		commits, err := c.listCommits(ctx, owner, repo, filter.Branch, filter.Since, limit)
+		if err != nil {
+			continue
+		}
+		all = append(all, commits...)
+		if len(all) >= limit {
+			return all[:limit], nil
+		}
+	}
+	return all, nil
+}
+
+// listCommits lists commits newest first. Older instances ignore the since
+// parameter, so commits before it are also dropped here.
+func (c *Client) listCommits(ctx context.Context, owner, repo, branch string, since *time.Time, limit int) ([]entity.Commit, error) {
+	query := url.Values{"stat": {"false"}, "verification": {"false"}, "files": {"false"}}
+	if branch != "" {
+		query.Set("sha", branch)
+	}
+	if since != nil {
+		query.Set("since", since.Format(time.RFC3339))
+	}
+
+	items, err := listPages[apiCommit](ctx, c, repository(owner, repo)+"/commits", query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]entity.Commit, 0, len(items))
+	for _, item := range items {
+		if since != nil && item.Commit.Author.Date.Before(*since) {
+			continue
+		}
+		commits = append(commits, toCommit(item, owner+"/"+repo, branch))
+	}
+	return commits, nil
+}
+
+// GetCommit returns a commit with its stats and changed files.
+func (c *Client) GetCommit(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+	var item apiCommit
+	path := repository(owner, repo) + "/git/commits/" + url.PathEscape(sha)
+	if _, err := c.do(ctx, http.MethodGet, path, url.Values{"stat": {"true"}, "files": {"true"}}, nil, &item); err != nil {
+		return nil, err
+	}
+	commit := toCommit(item, owner+"/"+repo, "")
+	return &commit, nil
+}
+
+// ListWorkflowRuns lists Actions runs, newest first. Gitea cannot filter
+// runs by workflow, so filter.Workflow is matched here against each run's
+// workflow file.
+func (c *Client) ListWorkflowRuns(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+	limit := 10
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	owner, repo, ok := strings.Cut(filter.Repository, "/")
+	if !ok {
+		return nil, nil
+	}
+
+	query := url.Values{"limit": {strconv.Itoa(maxPerPage)}}
+	if filter.Branch != "" {
+		query.Set("branch", filter.Branch)
+	}
+
+	var runs []entity.WorkflowRun
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var result apiWorkflowRuns
+		resp, err := c.do(ctx, http.MethodGet, repository(owner, repo)+"/actions/runs", query, nil, &result)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range result.WorkflowRuns {
+			if filter.Workflow != "" && workflowFile(r.Path) != filter.Workflow {
+				continue
+			}
+			runs = append(runs, toWorkflowRun(r, filter.Repository))
+			if len(runs) == limit {
+				return runs, nil
+			}
+		}
+		if len(result.WorkflowRuns) == 0 || !hasNextPage(resp) {
+			return runs, nil
+		}
+	}
+}
+
+func (c *Client) GetWorkflowRun(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+	var r apiWorkflowRun
+	path := repository(owner, repo) + "/actions/runs/" + strconv.FormatInt(runID, 10)
+	if _, err := c.do(ctx, http.MethodGet, path, nil, nil, &r); err != nil {
+		return nil, err
+	}
+	run := toWorkflowRun(r, owner+"/"+repo)
+	return &run, nil
+}
+
+// GetWorkflow looks up a workflow by its file name. Gitea workflows have no
+// numeric ID.
+func (c *Client) GetWorkflow(ctx context.Context, owner, repo, workflowID string) (*entity.Workflow, error) {
+	var w apiWorkflow
+	path := repository(owner, repo) + "/actions/workflows/" + url.PathEscape(workflowID)
+	if _, err := c.do(ctx, http.MethodGet, path, nil, nil, &w); err != nil {
+		return nil, err
+	}
+	return &entity.Workflow{
+		Name:      w.Name,
+		Path:      w.Path,
+		State:     w.State,
+		HTMLURL:   w.HTMLURL,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}, nil
+}
+
+// RerunWorkflow is not supported: Gitea has no API to re-run a run.
+func (c *Client) RerunWorkflow(ctx context.Context, owner, repo string, runID int64) error {
+	return &port.UnsupportedError{Provider: provider, Operation: "re-running workflow runs"}
+}
+
+func (c *Client) TriggerWorkflow(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error {
+	path := repository(owner, repo) + "/actions/workflows/" + url.PathEscape(workflowID) + "/dispatches"
+	_, err := c.do(ctx, http.MethodPost, path, nil, map[string]any{
+		"ref":    ref,
+		"inputs": inputs,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("triggered workflow", "repo", owner+"/"+repo, "workflow", workflowID, "ref", ref)
+	return nil
+}
+
+// CompareBranches compares head to base from their merge base, like
+// GitHub's three-dot compare. Gitea reports no behind count, so a second
+// compare in the other direction provides it. Files are those the compared
+// commits touch, without line counts.
+func (c *Client) CompareBranches(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+	ahead, err := c.compare(ctx, owner, repo, base, head)
+	if err != nil {
+		return nil, err
+	}
+	behind, err := c.compare(ctx, owner, repo, head, base)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []entity.Commit
+	var files []entity.ChangedFile
+	seen := make(map[string]bool)
+	for _, item := range ahead.Commits {
+		commit := toCommit(item, owner+"/"+repo, head)
+		for _, f := range commit.Files {
+			if !seen[f.Filename] {
+				seen[f.Filename] = true
+				files = append(files, f)
+			}
+		}
+		commit.Files = nil
+		commits = append(commits, commit)
+	}
+
+	aheadBy, behindBy := ahead.TotalCommits, behind.TotalCommits
+	status := "identical"
+	switch {
+	case aheadBy > 0 && behindBy > 0:
+		status = "diverged"
+	case aheadBy > 0:
+		status = "ahead"
+	case behindBy > 0:
+		status = "behind"
+	}
+
+	return &entity.BranchComparison{
+		Repository:   owner + "/" + repo,
+		ProdBranch:   base,
+		DevBranch:    head,
+		AheadBy:      aheadBy,
+		BehindBy:     behindBy,
+		TotalCommits: aheadBy,
+		GitHubStatus: status,
+		Commits:      commits,
+		Files:        files,
+		// Files are gathered from the commits, so missing commits mean
+		// missing files too.
+		FilesTruncated:   len(ahead.Commits) < aheadBy,
+		CommitsTruncated: len(ahead.Commits) < aheadBy,
+	}, nil
+}
+
+func (c *Client) compare(ctx context.Context, owner, repo, base, head string) (*apiCompare, error) {
+	var result apiCompare
+	path := repository(owner, repo) + "/compare/" + url.PathEscape(base) + "..." + url.PathEscape(head)
+	if _, err := c.do(ctx, http.MethodGet, path, url.Values{"files": {"true"}}, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) CreateBranch(ctx context.Context, owner, repo, branch, fromBranch string) error {
+	_, err := c.do(ctx, http.MethodPost, repository(owner, repo)+"/branches", nil, map[string]string{
+		"new_branch_name": branch,
+		"old_branch_name": fromBranch,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create branch '%s' from '%s': %w", branch, fromBranch, err)
+	}
+
+	c.logger.Info("created branch", "repo", owner+"/"+repo, "branch", branch, "from", fromBranch)
+	return nil
+}
+
+func (c *Client) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	_, err := c.do(ctx, http.MethodDelete, repository(owner, repo)+"/branches/"+url.PathEscape(branch), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("deleted branch", "repo", owner+"/"+repo, "branch", branch)
+	return nil
+}
+
+// CheckRevert reports that Gitea has no API to revert a commit.
+func (c *Client) CheckRevert(ctx context.Context, owner, repo string) error {
+	return &port.UnsupportedError{Provider: provider, Operation: "reverting commits"}
+}
+
+// RevertCommit is not supported, see CheckRevert.
+func (c *Client) RevertCommit(ctx context.Context, owner, repo, branch, sha string) (*entity.Commit, error) {
+	return nil, c.CheckRevert(ctx, owner, repo)
+}
+
+func (c *Client) GetCurrentUser(ctx context.Context) (string, error) {
+	var user apiUser
+	if _, err := c.do(ctx, http.MethodGet, "/user", nil, nil, &user); err != nil {
+		return "", err
+	}
+	return user.Login, nil
+}
@@ -0,0 +1,242 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// newFakeGitea serves routes, keyed by "METHOD path", under /api/v1 and
+// rejects requests without the test token.
+func newFakeGitea(t *testing.T, routes map[string]http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token gitea-test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"token is required"}`))
+			return
+		}
+		handler, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"GetRepositoryByName"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "gitea-test", logging.NewNoop())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestClient_ListRepositoriesPaginates(t *testing.T) {
+	client := newFakeGitea(t, map[string]http.HandlerFunc{
+		"GET /api/v1/user/repos": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("Link", `<http://gitea/api/v1/user/repos?page=2>; rel="next"`)
+				w.Write([]byte(`[{"id":1,"name":"api","full_name":"acme/api","private":true,"default_branch":"main"}]`))
+				return
+			}
+			w.Write([]byte(`[{"id":2,"name":"old","full_name":"acme/old","archived":true},{"id":3,"name":"web","full_name":"acme/web","fork":true}]`))
+		},
+	})
+
+	repos, err := client.ListRepositories(context.Background(), "", false)
+	if err != nil {
+		t.Fatalf("ListRepositories: %v", err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("ListRepositories() = %v, want 2 unarchived repositories", repos)
+	}
+	if repos[0].FullName != "acme/api" || !repos[0].Private || repos[0].DefaultBranch != "main" {
+		t.Errorf("repos[0] = %+v, want private acme/api on main", repos[0])
+	}
+	if repos[1].FullName != "acme/web" || !repos[1].Fork {
+		t.Errorf("repos[1] = %+v, want fork acme/web", repos[1])
+	}
+}
+
+func TestClient_ListPullRequests(t *testing.T) {
+	client := newFakeGitea(t, map[string]http.HandlerFunc{
+		"GET /api/v1/repos/acme/api/pulls": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("state") != "open" {
+				t.Errorf("state = %q, want open", r.URL.Query().Get("state"))
+			}
+			w.Write([]byte(`[{"id":10,"number":3,"title":"Add cache","state":"open","draft":true,
+				"user":{"login":"dev"},"head":{"ref":"feature"},"base":{"ref":"main"},
+				"mergeable":true,"changed_files":4,"labels":[{"name":"perf"}],
+				"requested_reviewers":[{"login":"lead"}]}]`))
+		},
+	})
+
+	prs, err := client.ListPullRequests(context.Background(), entity.PRFilter{Repository: "acme/api"})
+	if err != nil {
+		t.Fatalf("ListPullRequests: %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("ListPullRequests() = %v, want 1", prs)
+	}
+	pr := prs[0]
+	if pr.Number != 3 || !pr.Draft || pr.User != "dev" || pr.HeadBranch != "feature" || pr.BaseBranch != "main" {
+		t.Errorf("PR = %+v, want draft #3 from feature into main", pr)
+	}
+	if pr.Mergeable == nil || !*pr.Mergeable || pr.ChangedFiles != 4 {
+		t.Errorf("PR = %+v, want mergeable with 4 changed files", pr)
+	}
+	if len(pr.Labels) != 1 || len(pr.Reviewers) != 1 || pr.Reviewers[0] != "lead" {
+		t.Errorf("Labels = %v, Reviewers = %v, want [perf] and [lead]", pr.Labels, pr.Reviewers)
+	}
+}
+
+func TestClient_MergePullRequest(t *testing.T) {
+	var got map[string]any
+	client := newFakeGitea(t, map[string]http.HandlerFunc{
+		"POST /api/v1/repos/acme/api/pulls/4/merge": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&got)
+		},
+		"GET /api/v1/repos/acme/api/pulls/4": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"number":4,"state":"closed","merged":true,"merge_commit_sha":"abc123","html_url":"https://git.example.com/acme/api/pulls/4"}`))
+		},
+	})
+
+	result, err := client.MergePullRequest(context.Background(), "acme", "api", 4, "rebase", "")
+	if err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+	if got["Do"] != "rebase" {
+		t.Errorf("request = %v, want Do rebase", got)
+	}
+	if !result.Success || result.SHA != "abc123" || result.PRURL == "" {
+		t.Errorf("result = %+v, want merged at abc123 with URL", result)
+	}
+}
+
+func TestClient_ListWorkflowRunsFiltersByWorkflow(t *testing.T) {
+	client := newFakeGitea(t, map[string]http.HandlerFunc{
+		"GET /api/v1/repos/acme/api/actions/runs": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("branch") != "main" {
+				t.Errorf("branch = %q, want main", r.URL.Query().Get("branch"))
+			}
+			w.Write([]byte(`{"total_count":3,"workflow_runs":[
+				{"id":30,"path":"deploy.yml@refs/heads/main","status":"in_progress","event":"push","run_attempt":1},
+				{"id":20,"path":"lint.yml@refs/heads/main","status":"completed","conclusion":"success","event":"push","run_attempt":1},
+				{"id":10,"path":"deploy.yml@refs/heads/main","status":"completed","conclusion":"failure","event":"workflow_dispatch","run_attempt":2,
+				 "started_at":"2024-05-01T10:00:00Z","completed_at":"2024-05-01T10:05:00Z"}
+			]}`))
+		},
+	})
+
+	runs, err := client.ListWorkflowRuns(context.Background(), entity.CIFilter{
+		Repository: "acme/api",
+		Branch:     "main",
+		Workflow:   "deploy.yml",
+	})
+	if err != nil {
+		t.Fatalf("ListWorkflowRuns: %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != 30 || runs[1].ID != 10 {
+		t.Fatalf("ListWorkflowRuns() = %+v, want deploy.yml runs 30 and 10", runs)
+	}
+	failed := runs[1]
+	if failed.WorkflowName != "deploy.yml" || failed.Conclusion != "failure" || failed.RunAttempt != 2 {
+		t.Errorf("runs[1] = %+v, want failed second attempt of deploy.yml", failed)
+	}
+	if !failed.UpdatedAt.After(failed.RunStartedAt) {
+		t.Errorf("UpdatedAt = %v, want completion after start %v", failed.UpdatedAt, failed.RunStartedAt)
+	}
+}
+
+func TestClient_CompareBranches(t *testing.T) {
+	client := newFakeGitea(t, map[string]http.HandlerFunc{
+		"GET /api/v1/repos/acme/api/compare/main...develop": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"total_commits":2,"commits":[
+				{"sha":"a2","commit":{"message":"fix"},"files":[{"filename":"api.go","status":"modified"}]},
+				{"sha":"a1","commit":{"message":"feat"},"files":[{"filename":"api.go","status":"added"},{"filename":"old.go","status":"deleted"}]}
+			]}`))
+		},
+		"GET /api/v1/repos/acme/api/compare/develop...main": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"total_commits":0,"commits":[]}`))
+		},
+	})
+
+	comparison, err := client.CompareBranches(context.Background(), "acme", "api", "main", "develop")
+	if err != nil {
+		t.Fatalf("CompareBranches: %v", err)
+	}
+	if comparison.AheadBy != 2 || comparison.BehindBy != 0 || comparison.GitHubStatus != "ahead" {
+		t.Errorf("comparison = ahead %d, behind %d, %s; want ahead 2, behind 0, ahead",
+			comparison.AheadBy, comparison.BehindBy, comparison.GitHubStatus)
+	}
+	if len(comparison.Commits) != 2 {
+		t.Errorf("Commits = %v, want 2", comparison.Commits)
+	}
+	if len(comparison.Files) != 2 || comparison.Files[1].Status != "removed" {
+		t.Errorf("Files = %+v, want api.go once and old.go removed", comparison.Files)
+	}
+}
+
+func TestClient_DeleteBranch(t *testing.T) {
+	deleted := false
+	client := newFakeGitea(t, map[string]http.HandlerFunc{
+		"DELETE /api/v1/repos/acme/api/branches/sync/main": func(w http.ResponseWriter, r *http.Request) {
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	if err := client.DeleteBranch(context.Background(), "acme", "api", "sync/main"); err != nil {
+		t.Fatalf("DeleteBranch: %v", err)
+	}
+	if !deleted {
+		t.Error("DeleteBranch did not reach the branch endpoint")
+	}
+}
+
+func TestClient_UnsupportedOperations(t *testing.T) {
+	client := newFakeGitea(t, nil)
+	ctx := context.Background()
+
+	err := client.RerunWorkflow(ctx, "acme", "api", 10)
+	var unsupported *port.UnsupportedError
+	if !errors.As(err, &unsupported) || unsupported.Provider != "gitea" {
+		t.Errorf("RerunWorkflow() error = %v, want UnsupportedError from gitea", err)
+	}
+
+	_, err = client.RevertCommit(ctx, "acme", "api", "main", "abc123")
+	if !errors.Is(err, port.ErrUnsupported) {
+		t.Errorf("RevertCommit() error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestClient_TranslatesErrors(t *testing.T) {
+	client := newFakeGitea(t, nil)
+	ctx := context.Background()
+
+	_, err := client.GetRepository(ctx, "acme", "missing")
+	if !errors.Is(err, port.ErrNotFound) {
+		t.Errorf("GetRepository() error = %v, want ErrNotFound", err)
+	}
+
+	client.token = "wrong"
+	_, err = client.GetCurrentUser(ctx)
+	if !errors.Is(err, port.ErrUnauthorized) {
+		t.Errorf("GetCurrentUser() error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestClient_ImplementsPort(t *testing.T) {
+	var _ port.GitHubClient = (*Client)(nil)
+}
@@ -0,0 +1,258 @@
+package gitea
+
+import (
+	"strings"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+// API response types, limited to the fields the client maps.
+
+type apiRepository struct {
+	ID              int64     `json:"id"`
+	Name            string    `json:"name"`
+	FullName        string    `json:"full_name"`
+	Description     string    `json:"description"`
+	Private         bool      `json:"private"`
+	Archived        bool      `json:"archived"`
+	Fork            bool      `json:"fork"`
+	DefaultBranch   string    `json:"default_branch"`
+	StarsCount      int       `json:"stars_count"`
+	ForksCount      int       `json:"forks_count"`
+	OpenIssuesCount int       `json:"open_issues_count"`
+	HTMLURL         string    `json:"html_url"`
+	CloneURL        string    `json:"clone_url"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type apiUser struct {
+	Login string `json:"login"`
+}
+
+type apiBranchRef struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+type apiPullRequest struct {
+	ID             int64        `json:"id"`
+	Number         int          `json:"number"`
+	Title          string       `json:"title"`
+	Body           string       `json:"body"`
+	State          string       `json:"state"`
+	Draft          bool         `json:"draft"`
+	HTMLURL        string       `json:"html_url"`
+	User           *apiUser     `json:"user"`
+	Head           apiBranchRef `json:"head"`
+	Base           apiBranchRef `json:"base"`
+	Mergeable      bool         `json:"mergeable"`
+	Merged         bool         `json:"merged"`
+	MergeCommitSHA string       `json:"merge_commit_sha"`
+	Additions      int          `json:"additions"`
+	Deletions      int          `json:"deletions"`
+	ChangedFiles   int          `json:"changed_files"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	MergedAt       *time.Time   `json:"merged_at"`
+	ClosedAt       *time.Time   `json:"closed_at"`
+	Labels         []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	RequestedReviewers []apiUser `json:"requested_reviewers"`
+}
+
+type apiCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name  string    `json:"name"`
+			Email string    `json:"email"`
+			Date  time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+	Parents []struct {
+		SHA string `json:"sha"`
+	} `json:"parents"`
+	Files []apiAffectedFile `json:"files"`
+	Stats *struct {
+		Additions int `json:"additions"`
+		Deletions int `json:"deletions"`
+	} `json:"stats"`
+}
+
+type apiAffectedFile struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+}
+
+type apiCompare struct {
+	TotalCommits int         `json:"total_commits"`
+	Commits      []apiCommit `json:"commits"`
+}
+
+type apiWorkflowRun struct {
+	ID           int64     `json:"id"`
+	HTMLURL      string    `json:"html_url"`
+	DisplayTitle string    `json:"display_title"`
+	Path         string    `json:"path"` // Workflow file and ref, e.g. build.yml@refs/heads/main
+	Event        string    `json:"event"`
+	RunAttempt   int       `json:"run_attempt"`
+	RunNumber    int       `json:"run_number"`
+	HeadSHA      string    `json:"head_sha"`
+	HeadBranch   string    `json:"head_branch"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	Actor        *apiUser  `json:"actor"`
+	StartedAt    time.Time `json:"started_at"`
+	CompletedAt  time.Time `json:"completed_at"`
+}
+
+type apiWorkflowRuns struct {
+	WorkflowRuns []apiWorkflowRun `json:"workflow_runs"`
+	TotalCount   int              `json:"total_count"`
+}
+
+type apiWorkflow struct {
+	ID        string    `json:"id"` // The workflow file name
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	State     string    `json:"state"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func toRepository(r apiRepository) entity.Repository {
+	return entity.Repository{
+		ID:            r.ID,
+		Name:          r.Name,
+		FullName:      r.FullName,
+		Description:   r.Description,
+		Private:       r.Private,
+		Archived:      r.Archived,
+		Fork:          r.Fork,
+		DefaultBranch: r.DefaultBranch,
+		Stars:         r.StarsCount,
+		Forks:         r.ForksCount,
+		OpenIssues:    r.OpenIssuesCount,
+		HTMLURL:       r.HTMLURL,
+		CloneURL:      r.CloneURL,
+		UpdatedAt:     r.UpdatedAt,
+		PushedAt:      r.UpdatedAt,
+	}
+}
+
+func toPullRequest(pr apiPullRequest, repo string) entity.PullRequest {
+	result := entity.PullRequest{
+		ID:           pr.ID,
+		Number:       pr.Number,
+		Title:        pr.Title,
+		Body:         pr.Body,
+		State:        pr.State,
+		Draft:        pr.Draft,
+		HTMLURL:      pr.HTMLURL,
+		HeadBranch:   pr.Head.Ref,
+		BaseBranch:   pr.Base.Ref,
+		Additions:    pr.Additions,
+		Deletions:    pr.Deletions,
+		ChangedFiles: pr.ChangedFiles,
+		CreatedAt:    pr.CreatedAt,
+		UpdatedAt:    pr.UpdatedAt,
+		MergedAt:     pr.MergedAt,
+		ClosedAt:     pr.ClosedAt,
+		Repository:   repo,
+	}
+	if pr.User != nil {
+		result.User = pr.User.Login
+	}
+	// Gitea reports mergeable as false once merged or closed, so it is
+	// only meaningful for open pull requests.
+	if pr.State == "open" {
+		mergeable := pr.Mergeable
+		result.Mergeable = &mergeable
+	}
+	for _, l := range pr.Labels {
+		result.Labels = append(result.Labels, l.Name)
+	}
+	for _, r := range pr.RequestedReviewers {
+		result.Reviewers = append(result.Reviewers, r.Login)
+	}
+	return result
+}
+
+func toCommit(c apiCommit, repo, branch string) entity.Commit {
+	commit := entity.Commit{
+		SHA:         c.SHA,
+		Message:     c.Commit.Message,
+		Author:      c.Commit.Author.Name,
+		AuthorEmail: c.Commit.Author.Email,
+		Date:        c.Commit.Author.Date,
+		HTMLURL:     c.HTMLURL,
+		Repository:  repo,
+		Branch:      branch,
+	}
+	for _, p := range c.Parents {
+		commit.Parents = append(commit.Parents, p.SHA)
+	}
+	if c.Stats != nil {
+		commit.Additions = c.Stats.Additions
+		commit.Deletions = c.Stats.Deletions
+	}
+	for _, f := range c.Files {
+		commit.Files = append(commit.Files, toChangedFile(f))
+	}
+	return commit
+}
+
+// toChangedFile maps an affected file. Gitea reports no per-file line
+// counts or patch, only the name and status.
+func toChangedFile(f apiAffectedFile) entity.ChangedFile {
+	status := f.Status
+	if status == "deleted" {
+		status = "removed"
+	}
+	return entity.ChangedFile{
+		Filename: f.Filename,
+		Status:   status,
+	}
+}
+
+// toWorkflowRun maps an Actions run. Gitea already reports status,
+// conclusion and event in GitHub's vocabulary.
+func toWorkflowRun(r apiWorkflowRun, repo string) entity.WorkflowRun {
+	workflow := workflowFile(r.Path)
+
+	run := entity.WorkflowRun{
+		ID:           r.ID,
+		Name:         r.DisplayTitle,
+		WorkflowName: workflow,
+		HeadBranch:   r.HeadBranch,
+		HeadSHA:      r.HeadSHA,
+		Status:       r.Status,
+		Conclusion:   r.Conclusion,
+		HTMLURL:      r.HTMLURL,
+		RunNumber:    r.RunNumber,
+		RunAttempt:   r.RunAttempt,
+		CreatedAt:    r.StartedAt,
+		UpdatedAt:    r.StartedAt,
+		RunStartedAt: r.StartedAt,
+		Repository:   repo,
+		Event:        r.Event,
+	}
+	if !r.CompletedAt.IsZero() {
+		run.UpdatedAt = r.CompletedAt
+	}
+	if r.Actor != nil {
+		run.Actor = r.Actor.Login
+	}
+	return run
+}
+
+// workflowFile returns the workflow file name of a run's path.
+func workflowFile(path string) string {
+	file, _, _ := strings.Cut(path, "@")
+	return file
+}
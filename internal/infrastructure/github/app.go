@@ -0,0 +1,348 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/cache"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+	"github.com/google/go-github/v60/github"
+)
+
+// Credentials authenticate the client, either with a token or as a GitHub
+// App. App takes precedence when both are set.
+type Credentials struct {
+	Account string // Name of the account, keeping its stored responses apart from other accounts'
+	Token   string
+	App     *AppCredentials
+}
+
+// AppCredentials identify a GitHub App. Requests are made with installation
+// tokens, picking the installation on the account that owns the repository,
+// so one client can work across every organization the app is installed on.
+type AppCredentials struct {
+	AppID          int64
+	PrivateKey     []byte // PEM-encoded RSA key, PKCS#1 or PKCS#8
+	InstallationID int64  // Installation for requests not tied to an owner; optional when there is only one
+}
+
+const (
+	// GitHub rejects app JWTs valid for more than 10 minutes. The issue time
+	// is backdated to allow for clock drift.
+	jwtLifetime = 9 * time.Minute
+	jwtBackdate = 1 * time.Minute
+
+	// Installation tokens live an hour and are replaced this long before
+	// they expire, so a token never runs out during a request.
+	tokenRefreshMargin = 5 * time.Minute
+
+	// How often an unknown owner may trigger a new listing of installations.
+	installationRelistInterval = 1 * time.Minute
+)
+
+type installationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// appAuth signs app JWTs and keeps installation tokens fresh.
+type appAuth struct {
+	appID               int64
+	key                 *rsa.PrivateKey
+	defaultInstallation int64
+	gh                  *github.Client // Authenticated with the app JWT
+	group               cache.Group
+	logger              *logging.Logger
+
+	tokenMu sync.Mutex
+	tokens  map[int64]installationToken
+
+	installMu     sync.Mutex
+	installations map[string]int64 // Account login, lowercased, to installation ID
+	listedAt      time.Time
+}
+
+func newAppAuth(creds AppCredentials, apiURL *url.URL, base http.RoundTripper, logger *logging.Logger) (*appAuth, error) {
+	if creds.AppID == 0 {
+		return nil, errors.New("GitHub App ID is required")
+	}
+	key, err := parsePrivateKey(creds.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &appAuth{
+		appID:               creds.AppID,
+		key:                 key,
+		defaultInstallation: creds.InstallationID,
+		tokens:              make(map[int64]installationToken),
+		logger:              logger.WithComponent("github-app"),
+	}
+	a.gh = github.NewClient(&http.Client{Transport: &jwtTransport{base: base, app: a}})
+	a.gh.BaseURL = apiURL
+
+	return a, nil
+}
+
+// parsePrivateKey decodes a PEM RSA key as GitHub issues it (PKCS#1) or
+// after conversion to PKCS#8.
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("GitHub App private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("GitHub App private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// jwt returns a JWT signed with the app's key, which authenticates requests
+// made as the app itself.
+func (a *appAuth) jwt() (string, error) {
+	now := time.Now()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]any{
+		"iat": now.Add(-jwtBackdate).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": strconv.FormatInt(a.appID, 10),
+	})
+
+	enc := base64.RawURLEncoding
+	signed := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign GitHub App JWT: %w", err)
+	}
+
+	return signed + "." + enc.EncodeToString(signature), nil
+}
+
+// token returns an installation token valid for at least tokenRefreshMargin,
+// creating one when needed. Concurrent requests share one exchange.
+func (a *appAuth) token(ctx context.Context, installationID int64) (string, error) {
+	a.tokenMu.Lock()
+	cached, ok := a.tokens[installationID]
+	a.tokenMu.Unlock()
+	if ok && time.Until(cached.expiresAt) > tokenRefreshMargin {
+		return cached.token, nil
+	}
+
+	key := strconv.FormatInt(installationID, 10)
+	v, err, _ := a.group.Do(key, func() (any, error) {
+		token, _, err := a.gh.Apps.CreateInstallationToken(context.WithoutCancel(ctx), installationID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("create token for installation %d: %w", installationID, translateError(err))
+		}
+
+		fresh := installationToken{token: token.GetToken(), expiresAt: token.GetExpiresAt().Time}
+		a.tokenMu.Lock()
+		a.tokens[installationID] = fresh
+		a.tokenMu.Unlock()
+
+		a.logger.Debug("installation token created",
+			"installation", installationID,
+			"expires_at", fresh.expiresAt.Format(time.RFC3339),
+		)
+		return fresh.token, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// installationFor returns the installation on owner's account. The list of
+// installations is fetched again when owner is missing from it, at most once
+// per installationRelistInterval.
+func (a *appAuth) installationFor(ctx context.Context, owner string) (int64, error) {
+	a.installMu.Lock()
+	defer a.installMu.Unlock()
+
+	owner = strings.ToLower(owner)
+	if id, ok := a.installations[owner]; ok {
+		return id, nil
+	}
+
+	if a.installations == nil || time.Since(a.listedAt) > installationRelistInterval {
+		if err := a.listInstallations(ctx); err != nil {
+			return 0, err
+		}
+		if id, ok := a.installations[owner]; ok {
+			return id, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: GitHub App %d is not installed on %s", port.ErrUnauthorized, a.appID, owner)
+}
+
+// installationIDs returns every installation of the app.
+func (a *appAuth) installationIDs(ctx context.Context) ([]int64, error) {
+	a.installMu.Lock()
+	defer a.installMu.Unlock()
+
+	if a.installations == nil {
+		if err := a.listInstallations(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]int64, 0, len(a.installations))
+	for _, id := range a.installations {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// defaultInstallationID returns the installation used for requests not tied
+// to an owner: the configured one, or the app's only installation.
+func (a *appAuth) defaultInstallationID(ctx context.Context) (int64, error) {
+	if a.defaultInstallation != 0 {
+		return a.defaultInstallation, nil
+	}
+
+	ids, err := a.installationIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) != 1 {
+		return 0, fmt.Errorf("GitHub App %d has %d installations; set an installation ID for requests not tied to a repository owner", a.appID, len(ids))
+	}
+	return ids[0], nil
+}
+
+// listInstallations refreshes the installation map. Callers hold installMu.
+func (a *appAuth) listInstallations(ctx context.Context) error {
+	installations := make(map[string]int64)
+	opts := &github.ListOptions{PerPage: maxPerPage}
+	for {
+		page, resp, err := a.gh.Apps.ListInstallations(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("list GitHub App installations: %w", translateError(err))
+		}
+		for _, inst := range page {
+			installations[strings.ToLower(inst.GetAccount().GetLogin())] = inst.GetID()
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	a.installations = installations
+	a.listedAt = time.Now()
+	a.logger.Debug("listed installations", "count", len(installations))
+	return nil
+}
+
+// slug returns the app's slug, for reporting who the client acts as.
+func (a *appAuth) slug(ctx context.Context) (string, error) {
+	app, _, err := a.gh.Apps.Get(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	return app.GetSlug(), nil
+}
+
+// jwtTransport authenticates requests as the app itself.
+type jwtTransport struct {
+	base http.RoundTripper
+	app  *appAuth
+}
+
+func (t *jwtTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	jwt, err := t.app.jwt()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	return t.base.RoundTrip(req)
+}
+
+type installationKey struct{}
+
+// withInstallation makes requests made with ctx use an installation's token,
+// for endpoints such as /installation/repositories that name no owner.
+func withInstallation(ctx context.Context, installationID int64) context.Context {
+	return context.WithValue(ctx, installationKey{}, installationID)
+}
+
+// installationTransport authenticates requests with a token for the
+// installation on the account that owns the requested resource.
+type installationTransport struct {
+	base    http.RoundTripper
+	app     *appAuth
+	apiPath string // Path of the API root, stripped before reading the owner
+}
+
+func (t *installationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	installationID, ok := ctx.Value(installationKey{}).(int64)
+	if !ok {
+		var err error
+		if owner := ownerOf(req.URL.Path, t.apiPath); owner != "" {
+			installationID, err = t.app.installationFor(ctx, owner)
+		} else {
+			installationID, err = t.app.defaultInstallationID(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	token, err := t.app.token(ctx, installationID)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(ctx)
+	req.Header.Set("Authorization", "token "+token)
+	return t.base.RoundTrip(req)
+}
+
+// ownerOf returns the account named by an API path such as
+// /repos/{owner}/{repo}/..., /orgs/{org}/... or /users/{user}/..., or "" for
+// paths that name none.
+func ownerOf(path, apiPath string) string {
+	path = strings.TrimPrefix(path, strings.TrimSuffix(apiPath, "/"))
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+
+	switch parts[0] {
+	case "repos", "orgs", "users":
+		return parts[1]
+	default:
+		return ""
+	}
+}
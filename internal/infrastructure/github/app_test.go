@@ -0,0 +1,244 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// fakeAppServer plays GitHub for an app installed on acme (installation 1)
+// and globex (installation 2). It checks app JWTs and issues tokens that
+// expire after tokenTTL.
+type fakeAppServer struct {
+	*httptest.Server
+	key      *rsa.PrivateKey
+	tokenTTL time.Duration
+
+	mu        sync.Mutex
+	exchanges map[string]int    // Installation ID to tokens issued
+	auth      map[string]string // Request path to Authorization header
+}
+
+func newFakeAppServer(t *testing.T, tokenTTL time.Duration) *fakeAppServer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	s := &fakeAppServer{
+		key:       key,
+		tokenTTL:  tokenTTL,
+		exchanges: make(map[string]int),
+		auth:      make(map[string]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeAppServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	path := r.URL.Path
+
+	if strings.HasPrefix(path, "/app") {
+		if err := s.verifyJWT(r.Header.Get("Authorization")); err != nil {
+			http.Error(w, `{"message":"`+err.Error()+`"}`, http.StatusUnauthorized)
+			return
+		}
+	}
+
+	switch {
+	case path == "/app":
+		w.Write([]byte(`{"slug":"monitor"}`))
+	case path == "/app/installations":
+		w.Write([]byte(`[{"id":1,"account":{"login":"acme"}},{"id":2,"account":{"login":"Globex"}}]`))
+	case strings.HasSuffix(path, "/access_tokens"):
+		id := strings.Split(path, "/")[3]
+		s.exchanges[id]++
+		fmt.Fprintf(w, `{"token":"tok-%s-%d","expires_at":%q}`,
+			id, s.exchanges[id], time.Now().Add(s.tokenTTL).Format(time.RFC3339))
+	case path == "/installation/repositories":
+		owner := map[string]string{"token tok-1-1": "acme", "token tok-2-1": "globex"}[r.Header.Get("Authorization")]
+		fmt.Fprintf(w, `{"total_count":1,"repositories":[{"full_name":"%s/api"}]}`, owner)
+	case strings.HasPrefix(path, "/repos/"):
+		s.auth[path] = r.Header.Get("Authorization")
+		w.Write([]byte(`{"full_name":"` + strings.TrimPrefix(path, "/repos/") + `"}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *fakeAppServer) verifyJWT(header string) error {
+	jwt, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return errors.New("missing JWT")
+	}
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return errors.New("malformed JWT")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&s.key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return err
+	}
+	var claims struct {
+		Iss string `json:"iss"`
+		Exp int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return err
+	}
+	if claims.Iss != "42" || time.Unix(claims.Exp, 0).Before(time.Now()) {
+		return fmt.Errorf("bad claims %+v", claims)
+	}
+	return nil
+}
+
+func (s *fakeAppServer) client(t *testing.T) *Client {
+	t.Helper()
+
+	key := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(s.key)})
+	client, err := NewClient(Credentials{App: &AppCredentials{AppID: 42, PrivateKey: key}},
+		Endpoints{APIURL: s.URL, WebURL: s.URL}, nil, logging.NewNoop())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestAppAuth_PicksInstallationPerOwner(t *testing.T) {
+	server := newFakeAppServer(t, time.Hour)
+	client := server.client(t)
+	ctx := context.Background()
+
+	for _, repo := range []string{"acme/api", "globex/web", "acme/web"} {
+		owner, name, _ := strings.Cut(repo, "/")
+		if _, err := client.GetRepository(ctx, owner, name); err != nil {
+			t.Fatalf("GetRepository(%s): %v", repo, err)
+		}
+	}
+
+	want := map[string]string{
+		"/repos/acme/api":   "token tok-1-1",
+		"/repos/globex/web": "token tok-2-1",
+		"/repos/acme/web":   "token tok-1-1",
+	}
+	for path, auth := range want {
+		if server.auth[path] != auth {
+			t.Errorf("Authorization for %s = %q, want %q", path, server.auth[path], auth)
+		}
+	}
+	if server.exchanges["1"] != 1 {
+		t.Errorf("tokens issued for installation 1 = %d, want 1", server.exchanges["1"])
+	}
+}
+
+func TestAppAuth_RefreshesExpiringToken(t *testing.T) {
+	server := newFakeAppServer(t, tokenRefreshMargin/2)
+	client := server.client(t)
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := client.GetRepository(ctx, "acme", "api"); err != nil {
+			t.Fatalf("GetRepository: %v", err)
+		}
+	}
+
+	if server.exchanges["1"] != 2 {
+		t.Errorf("tokens issued = %d, want 2", server.exchanges["1"])
+	}
+	if got := server.auth["/repos/acme/api"]; got != "token tok-1-2" {
+		t.Errorf("Authorization = %q, want refreshed token", got)
+	}
+}
+
+func TestAppAuth_OwnerWithoutInstallation(t *testing.T) {
+	client := newFakeAppServer(t, time.Hour).client(t)
+
+	_, err := client.GetRepository(context.Background(), "initech", "api")
+	if !errors.Is(err, port.ErrUnauthorized) {
+		t.Errorf("GetRepository() error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestAppAuth_ListsEveryInstallation(t *testing.T) {
+	client := newFakeAppServer(t, time.Hour).client(t)
+
+	repos, err := client.ListRepositories(context.Background(), "", true)
+	if err != nil {
+		t.Fatalf("ListRepositories: %v", err)
+	}
+	if len(repos) != 2 {
+		t.Errorf("ListRepositories() = %v, want a repository from each installation", repos)
+	}
+
+	user, err := client.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentUser: %v", err)
+	}
+	if user != "monitor[bot]" {
+		t.Errorf("GetCurrentUser() = %q, want monitor[bot]", user)
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"PKCS#1", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), false},
+		{"PKCS#8", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), false},
+		{"not PEM", []byte("not a key"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePrivateKey(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parsePrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,64 +3,247 @@ package github
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
 	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
 	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/cache"
 	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
 )
 
-// CachedClient wraps a Client with caching capabilities.
+func init() {
+	// Value types stored by CachedClient, needed by the file cache backend.
+	cache.Register([]entity.Repository{})
+	cache.Register([]entity.PullRequest{})
+}
+
+// Cache key layout. Every key for a repository's data starts with a prefix
+// built from its name, so InvalidateRepository can drop them together.
+const (
+	reposKeyPrefix = "repos:"
+	prsNamespace   = "prs:"
+)
+
+func prsKeyPrefix(repository string) string {
+	return prsNamespace + repository + ":"
+}
+
+// cacheNamespaces maps the namespace names accepted by ClearNamespace to
+// their key prefixes.
+var cacheNamespaces = map[string]string{
+	"repos": reposKeyPrefix,
+	"prs":   prsNamespace,
+	"http":  responseKeyPrefix,
+}
+
+// CacheTTLs returns the time-to-live of each key namespace CachedClient
+// writes, for cache.Config.PrefixTTLs. Repository listings change rarely,
+// pull requests often.
+func CacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		reposKeyPrefix: 15 * time.Minute,
+		prsNamespace:   2 * time.Minute,
+	}
+}
+
+// CachedClient wraps a port.GitHubClient, such as a Client or a Router, with
+// caching capabilities. It works with any cache.Store backend.
+//
+// Concurrent misses for the same key share a single API call. With a stale
+// window set, an entry that expired less than the window ago is served right
+// away while one background call refreshes it.
 type CachedClient struct {
-	*Client
-	cache  *cache.Cache
-	logger *logging.Logger
+	port.GitHubClient
+	cache       cache.Store
+	responses   cache.Store // Responses kept for conditional requests, when not in cache
+	group       cache.Group
+	staleWindow time.Duration
+	generation  atomic.Uint64 // Bumped by invalidation so in-flight fetches do not store stale data
+	logger      *logging.Logger
 }
 
-// NewCachedClient creates a new cached client wrapper.
-func NewCachedClient(client *Client, cache *cache.Cache, logger *logging.Logger) *CachedClient {
+// NewCachedClient creates a new cached client wrapper. staleWindow enables
+// stale-while-revalidate; 0 disables it. The store must keep expired entries
+// for at least that long.
+func NewCachedClient(client port.GitHubClient, cache cache.Store, staleWindow time.Duration, logger *logging.Logger) *CachedClient {
 	return &CachedClient{
-		Client: client,
-		cache:  cache,
-		logger: logger.WithComponent("cached-client"),
+		GitHubClient: client,
+		cache:        cache,
+		staleWindow:  staleWindow,
+		logger:       logger.WithComponent("cached-client"),
 	}
 }
 
-// ListRepositories returns cached repositories or fetches from API.
-func (c *CachedClient) ListRepositories(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
-	key := fmt.Sprintf("repos:%s:%v", filter, includeArchived)
+// WithResponseStore tells the client that the responses kept for
+// conditional requests live in store rather than in its own cache, so that
+// clearing and stats cover them.
+func (c *CachedClient) WithResponseStore(store cache.Store) *CachedClient {
+	c.responses = store
+	return c
+}
 
-	if cached, ok := c.cache.Get(key); ok {
-		c.logger.Debug("cache hit", "key", key)
-		return cached.([]entity.Repository), nil
+// stores returns the cache and, if separate, the response store.
+func (c *CachedClient) stores() []cache.Store {
+	if c.responses == nil || c.responses == c.cache {
+		return []cache.Store{c.cache}
 	}
+	return []cache.Store{c.cache, c.responses}
+}
 
-	c.logger.Debug("cache miss", "key", key)
-	repos, err := c.Client.ListRepositories(ctx, filter, includeArchived)
-	if err != nil {
-		return nil, err
+// responseStore returns the store holding responses kept for conditional
+// requests.
+func (c *CachedClient) responseStore() cache.Store {
+	if c.responses != nil {
+		return c.responses
 	}
+	return c.cache
+}
+
+// ListRepositories returns cached repositories or fetches from API.
+func (c *CachedClient) ListRepositories(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+	key := fmt.Sprintf("%s%s:%v", reposKeyPrefix, filter, includeArchived)
 
-	c.cache.Set(key, repos)
-	return repos, nil
+	v, err := c.get(ctx, key, func(ctx context.Context) (any, error) {
+		return c.GitHubClient.ListRepositories(ctx, filter, includeArchived)
+	})
+	if err != nil && !port.IsPartial(err) {
+		return nil, err
+	}
+	return v.([]entity.Repository), err
 }
 
 // ListPullRequests returns cached PRs or fetches from API.
 func (c *CachedClient) ListPullRequests(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
-	key := fmt.Sprintf("prs:%s:%s:%d", filter.Repository, filter.State, filter.Limit)
+	key := fmt.Sprintf("%s%s:%d", prsKeyPrefix(filter.Repository), filter.State, filter.Limit)
 
-	if cached, ok := c.cache.Get(key); ok {
-		c.logger.Debug("cache hit", "key", key)
-		return cached.([]entity.PullRequest), nil
+	v, err := c.get(ctx, key, func(ctx context.Context) (any, error) {
+		return c.GitHubClient.ListPullRequests(ctx, filter)
+	})
+	if err != nil && !port.IsPartial(err) {
+		return nil, err
+	}
+	return v.([]entity.PullRequest), err
+}
+
+// get serves key from the cache, or through fetch on a miss. A max age set
+// with port.WithMaxAge turns older entries into misses and disables serving
+// stale entries.
+func (c *CachedClient) get(ctx context.Context, key string, fetch func(context.Context) (any, error)) (any, error) {
+	maxAge, limited := port.MaxAge(ctx)
+
+	if entry, ok := c.cache.GetEntry(key); ok {
+		if limited && entry.Age() > maxAge {
+			c.logger.Debug("cache entry too old for request", "key", key, "max_age", maxAge.String())
+		} else if !entry.IsExpired() {
+			c.logger.Debug("cache hit", "key", key)
+			return entry.Value, nil
+		} else if !limited && c.staleWindow > 0 && time.Since(entry.ExpiresAt) < c.staleWindow {
+			if c.group.Go(key, c.fetcher(context.Background(), key, fetch)) {
+				c.logger.Debug("cache stale, refreshing in background", "key", key)
+			}
+			return entry.Value, nil
+		}
 	}
 
 	c.logger.Debug("cache miss", "key", key)
-	prs, err := c.Client.ListPullRequests(ctx, filter)
-	if err != nil {
-		return nil, err
+
+	// The call is shared with other callers, so one caller's cancellation
+	// must not fail the others.
+	v, err, shared := c.group.Do(key, c.fetcher(context.WithoutCancel(ctx), key, fetch))
+	if shared {
+		c.logger.Debug("joined in-flight request", "key", key)
+	}
+	return v, err
+}
+
+// fetcher wraps fetch to store its result under key. Incomplete results,
+// returned with a *port.PartialError, are passed on but not stored, so the
+// next call retries the accounts that failed.
+func (c *CachedClient) fetcher(ctx context.Context, key string, fetch func(context.Context) (any, error)) func() (any, error) {
+	return func() (any, error) {
+		generation := c.generation.Load()
+
+		v, err := fetch(ctx)
+		if port.IsPartial(err) {
+			return v, err
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if c.generation.Load() == generation {
+			c.cache.Set(key, v)
+		}
+		return v, nil
+	}
+}
+
+// InvalidateRepository implements port.CacheInvalidator. Besides the
+// repository's own entries it drops the cross-repository PR listing and the
+// repository listings, whose counts and push times the write may change.
+func (c *CachedClient) InvalidateRepository(repository string) {
+	c.ClearRepository(repository)
+}
+
+// ClearRepository implements port.CacheManager. It also drops the responses
+// kept for conditional requests to the repository's endpoints.
+func (c *CachedClient) ClearRepository(repository string) int {
+	c.generation.Add(1)
+
+	removed := c.cache.DeletePrefix(prsKeyPrefix(repository))
+	removed += c.cache.DeletePrefix(prsKeyPrefix(""))
+	removed += c.cache.DeletePrefix(reposKeyPrefix)
+	if repository != "" {
+		removed += c.responseStore().DeletePrefix(responseRepoKeyPrefix(repository))
 	}
 
-	c.cache.Set(key, prs)
-	return prs, nil
+	c.logger.Debug("cache invalidated", "repo", repository, "entries", removed)
+	return removed
+}
+
+// ClearNamespace implements port.CacheManager. Namespaces are repos, prs and
+// http (responses kept for conditional requests).
+func (c *CachedClient) ClearNamespace(namespace string) (int, error) {
+	c.generation.Add(1)
+
+	if namespace == "" {
+		removed := 0
+		for _, store := range c.stores() {
+			removed += store.Size()
+			store.Clear()
+		}
+		c.logger.Info("cache cleared", "entries", removed)
+		return removed, nil
+	}
+
+	prefix, ok := cacheNamespaces[namespace]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s (expected repos, prs or http)", port.ErrUnknownCacheNamespace, namespace)
+	}
+
+	store := c.cache
+	if prefix == responseKeyPrefix {
+		store = c.responseStore()
+	}
+
+	removed := store.DeletePrefix(prefix)
+	c.logger.Info("cache namespace cleared", "namespace", namespace, "entries", removed)
+	return removed, nil
+}
+
+// CacheStats implements port.CacheManager.
+func (c *CachedClient) CacheStats() entity.CacheStats {
+	var total entity.CacheStats
+	for _, store := range c.stores() {
+		stats := store.Stats()
+		total.Hits += stats.Hits
+		total.Misses += stats.Misses
+		total.Evictions += stats.Evictions
+		total.Entries += stats.Entries
+		total.Bytes += stats.Bytes
+	}
+	return total
 }
 
 // Note: CompareBranches is NOT cached because it needs real-time data for drift detection.
@@ -0,0 +1,260 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/cache"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// fakeRepoServer serves a repository listing, counting requests and holding
+// each one until release is closed.
+type fakeRepoServer struct {
+	*httptest.Server
+	requests atomic.Int32
+	release  chan struct{}
+}
+
+func newFakeRepoServer(t *testing.T) *fakeRepoServer {
+	t.Helper()
+
+	s := &fakeRepoServer{release: make(chan struct{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		<-s.release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name":"api","full_name":"acme/api"}]`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newTestClient creates a client that sends its API requests to serverURL.
+func newTestClient(t *testing.T, serverURL string) *Client {
+	t.Helper()
+
+	client, err := NewClient(Credentials{Token: "token"}, Endpoints{APIURL: serverURL, WebURL: serverURL}, nil, logging.NewNoop())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func newTestCachedClient(t *testing.T, serverURL string, store cache.Store, staleWindow time.Duration) *CachedClient {
+	t.Helper()
+
+	return NewCachedClient(newTestClient(t, serverURL), store, staleWindow, logging.NewNoop())
+}
+
+func TestCachedClient_CoalescesConcurrentMisses(t *testing.T) {
+	server := newFakeRepoServer(t)
+	store := cache.New(cache.DefaultConfig())
+	defer store.Stop()
+	client := newTestCachedClient(t, server.URL, store, 0)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repos, err := client.ListRepositories(context.Background(), "", false)
+			if err != nil {
+				t.Errorf("ListRepositories() error = %v", err)
+				return
+			}
+			if len(repos) != 1 || repos[0].FullName != "acme/api" {
+				t.Errorf("ListRepositories() = %v, want [acme/api]", repos)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(server.release)
+	wg.Wait()
+
+	if got := server.requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestCachedClient_ServesStaleWhileRevalidating(t *testing.T) {
+	server := newFakeRepoServer(t)
+	store := cache.New(cache.Config{
+		DefaultTTL:     time.Minute,
+		CleanupTick:    time.Minute,
+		StaleRetention: time.Hour,
+	})
+	defer store.Stop()
+	client := newTestCachedClient(t, server.URL, store, time.Hour)
+
+	key := reposKeyPrefix + ":false"
+	store.SetWithTTL(key, []entity.Repository{{FullName: "acme/old"}}, -time.Second)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repos, err := client.ListRepositories(context.Background(), "", false)
+			if err != nil {
+				t.Errorf("ListRepositories() error = %v", err)
+				return
+			}
+			if len(repos) != 1 || repos[0].FullName != "acme/old" {
+				t.Errorf("ListRepositories() = %v, want stale [acme/old]", repos)
+			}
+		}()
+	}
+	wg.Wait()
+
+	close(server.release)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		entry, ok := store.GetEntry(key)
+		if ok && !entry.IsExpired() {
+			repos := entry.Value.([]entity.Repository)
+			if len(repos) != 1 || repos[0].FullName != "acme/api" {
+				t.Errorf("refreshed entry = %v, want [acme/api]", repos)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("entry was not refreshed in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := server.requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestCachedClient_HonorsMaxAge(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          func(context.Context) context.Context
+		wantName     string
+		wantRequests int32
+	}{
+		{"no limit", func(ctx context.Context) context.Context { return ctx }, "acme/cached", 0},
+		{"young enough", func(ctx context.Context) context.Context { return port.WithMaxAge(ctx, time.Hour) }, "acme/cached", 0},
+		{"too old", func(ctx context.Context) context.Context { return port.WithMaxAge(ctx, time.Millisecond) }, "acme/api", 1},
+		{"fresh", func(ctx context.Context) context.Context { return port.WithMaxAge(ctx, 0) }, "acme/api", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeRepoServer(t)
+			close(server.release)
+			store := cache.New(cache.DefaultConfig())
+			defer store.Stop()
+			client := newTestCachedClient(t, server.URL, store, time.Hour)
+
+			store.Set(reposKeyPrefix+":false", []entity.Repository{{FullName: "acme/cached"}})
+			time.Sleep(5 * time.Millisecond)
+
+			repos, err := client.ListRepositories(tt.ctx(context.Background()), "", false)
+			if err != nil {
+				t.Fatalf("ListRepositories() error = %v", err)
+			}
+
+			if len(repos) != 1 || repos[0].FullName != tt.wantName {
+				t.Errorf("ListRepositories() = %v, want [%s]", repos, tt.wantName)
+			}
+			if got := server.requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestCachedClient_SeparateResponseStore(t *testing.T) {
+	store := cache.New(cache.DefaultConfig())
+	defer store.Stop()
+	responses := cache.New(cache.DefaultConfig())
+	defer responses.Stop()
+	client := newTestCachedClient(t, "http://unused", store, 0).WithResponseStore(responses)
+
+	store.Set(reposKeyPrefix+":false", []entity.Repository{{FullName: "acme/api"}})
+	responses.Set(responseKeyPrefix+"acme:/repos/acme/api", &storedResponse{ETag: `"v1"`})
+
+	if got := client.CacheStats().Entries; got != 2 {
+		t.Errorf("CacheStats().Entries = %d, want 2 across both stores", got)
+	}
+
+	removed, err := client.ClearNamespace("http")
+	if err != nil {
+		t.Fatalf("ClearNamespace(http) error = %v", err)
+	}
+	if removed != 1 || responses.Size() != 0 || store.Size() != 1 {
+		t.Errorf("ClearNamespace(http) removed %d, left %d responses and %d entries; want 1, 0 and 1",
+			removed, responses.Size(), store.Size())
+	}
+
+	if removed, _ := client.ClearNamespace(""); removed != 1 || store.Size() != 0 {
+		t.Errorf("ClearNamespace() removed %d, left %d entries; want 1 and 0", removed, store.Size())
+	}
+}
+
+func TestCachedClient_ClearRepositoryDropsStoredResponses(t *testing.T) {
+	store := cache.New(cache.DefaultConfig())
+	defer store.Stop()
+	responses := cache.New(cache.DefaultConfig())
+	defer responses.Stop()
+	client := newTestCachedClient(t, "http://unused", store, 0).WithResponseStore(responses)
+
+	transport := &etagTransport{scope: "acme"}
+	for _, url := range []string{
+		"https://ghe.example.com/api/v3/repos/acme/api",
+		"https://ghe.example.com/api/v3/repos/acme/api/pulls?state=open",
+		"https://ghe.example.com/api/v3/repos/acme/api-web",
+		"https://ghe.example.com/api/v3/user/repos",
+	} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		responses.Set(transport.responseKey(req), &storedResponse{ETag: `"v1"`})
+	}
+
+	if removed := client.ClearRepository("Acme/API"); removed != 2 {
+		t.Errorf("ClearRepository() removed %d, want the 2 acme/api responses", removed)
+	}
+	if responses.Size() != 2 {
+		t.Errorf("left %d responses, want acme/api-web and /user/repos kept", responses.Size())
+	}
+}
+
+func TestCachedClient_DoesNotStorePartialListing(t *testing.T) {
+	store := cache.New(cache.DefaultConfig())
+	defer store.Stop()
+
+	calls := 0
+	mock := port.NewMockGitHubClient()
+	mock.ListRepositoriesFunc = func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+		calls++
+		return []entity.Repository{{FullName: "carlos/dotfiles"}}, &port.PartialError{Errs: []error{port.ErrUnauthorized}}
+	}
+	client := NewCachedClient(mock, store, 0, logging.NewNoop())
+
+	for i := 0; i < 2; i++ {
+		repos, err := client.ListRepositories(context.Background(), "", false)
+		if !port.IsPartial(err) {
+			t.Errorf("ListRepositories() error = %v, want the partial error passed on", err)
+		}
+		if len(repos) != 1 {
+			t.Errorf("ListRepositories() = %v, want the repositories that were listed", repos)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("listed %d times, want 2 since incomplete listings are not stored", calls)
+	}
+}
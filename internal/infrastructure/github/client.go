@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
 	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/cache"
 	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
 	"github.com/google/go-github/v60/github"
 	"golang.org/x/oauth2"
@@ -17,16 +21,23 @@ type Client struct {
 	gh          *github.Client
 	rateLimiter *RateLimiter
 	retryer     *Retryer
+	links       webLinks
+	app         *appAuth // Set when authenticated as a GitHub App
 	logger      *logging.Logger
 }
 
-// rateLimitTransport wraps http.RoundTripper to capture rate limit headers.
+// rateLimitTransport wraps http.RoundTripper to wait for the rate limiter
+// before each request and capture rate limit headers from the response.
 type rateLimitTransport struct {
 	base        http.RoundTripper
 	rateLimiter *RateLimiter
 }
 
 func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.rateLimiter.Wait(req.Context(), resourceFor(req)); err != nil {
+		return nil, err
+	}
+
 	resp, err := t.base.RoundTrip(req)
 	if resp != nil {
 		t.rateLimiter.UpdateFromResponse(resp)
@@ -34,82 +45,170 @@ func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error
 	return resp, err
 }
 
-func NewClient(token string, logger *logging.Logger) *Client {
-	clientLogger := logger.WithComponent("github")
-	rateLimiter := NewRateLimiter(10, logger)
-	retryer := NewRetryer(DefaultRetryConfig(), logger)
+// NewClient creates a GitHub client for the instance at endpoints; URLs left
+// empty default to github.com or derive from the others. When httpCache is
+// not nil, GET requests are made conditional and their responses are stored
+// in it for revalidation.
+func NewClient(creds Credentials, endpoints Endpoints, httpCache cache.Store, logger *logging.Logger) (*Client, error) {
+	endpoints = endpoints.withDefaults()
+	apiURL, err := parseBaseURL("API", endpoints.APIURL)
+	if err != nil {
+		return nil, err
+	}
+	uploadURL, err := parseBaseURL("upload", endpoints.UploadURL)
+	if err != nil {
+		return nil, err
+	}
+	webURL, err := parseBaseURL("web", endpoints.WebURL)
+	if err != nil {
+		return nil, err
+	}
 
-	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	baseClient := oauth2.NewClient(ctx, ts)
+	clientLogger := logger.WithComponent("github")
+	retryConfig := DefaultRetryConfig()
+	rateLimiter := NewRateLimiter(10, retryConfig.MaxWait, logger)
+	retryer := NewRetryer(retryConfig, logger)
+
+	// Authenticate as a GitHub App installation or with a static token
+	var app *appAuth
+	var authTransport http.RoundTripper
+	if creds.App != nil {
+		app, err = newAppAuth(*creds.App, apiURL, http.DefaultTransport, logger)
+		if err != nil {
+			return nil, err
+		}
+		authTransport = &installationTransport{
+			base:    http.DefaultTransport,
+			app:     app,
+			apiPath: apiURL.Path,
+		}
+	} else {
+		authTransport = &oauth2.Transport{
+			Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: creds.Token}),
+			Base:   http.DefaultTransport,
+		}
+	}
 
 	// Wrap transport to capture rate limit headers
-	baseClient.Transport = &rateLimitTransport{
-		base:        baseClient.Transport,
-		rateLimiter: rateLimiter,
+	baseClient := &http.Client{
+		Transport: &rateLimitTransport{
+			base:        authTransport,
+			rateLimiter: rateLimiter,
+		},
+	}
+
+	// Revalidate stored responses instead of refetching them
+	if httpCache != nil {
+		baseClient.Transport = &etagTransport{
+			base:   baseClient.Transport,
+			store:  httpCache,
+			scope:  creds.Account,
+			logger: clientLogger,
+		}
 	}
 
-	clientLogger.Info("github client initialized")
+	gh := github.NewClient(baseClient)
+	gh.BaseURL = apiURL
+	gh.UploadURL = uploadURL
+
+	clientLogger.Info("github client initialized",
+		"api_url", apiURL.String(),
+		"github_app", app != nil,
+	)
 
 	return &Client{
-		gh:          github.NewClient(baseClient),
+		gh:          gh,
 		rateLimiter: rateLimiter,
 		retryer:     retryer,
+		links:       webLinks{base: webURL},
+		app:         app,
 		logger:      clientLogger,
-	}
+	}, nil
 }
 
-func (c *Client) ListRepositories(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
-	c.rateLimiter.Wait()
+// Whether an operation can be retried after a failure that may have reached
+// GitHub, such as a timeout or a 5xx. Operations that are not idempotent are
+// only retried when GitHub rejected the request (rate limits), so dispatches
+// and merges never run twice.
+const (
+	idempotent    = true
+	notIdempotent = false
+)
+
+// do is the single path every API request goes through. Failures are
+// retried according to idempotency, and errors come back translated to port
+// errors. Each HTTP request also waits for the rate limiter in the transport.
+func (c *Client) do(ctx context.Context, operation string, idempotent bool, fn func() error) error {
+	return c.retryer.Do(ctx, operation, idempotent, fn)
+}
 
-	opts := &github.RepositoryListOptions{
-		Affiliation: "owner,organization_member",
-		Sort:        "updated",
-		ListOptions: github.ListOptions{PerPage: 100},
+func (c *Client) ListRepositories(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+	var repos []*github.Repository
+	var err error
+	if c.app != nil {
+		repos, err = c.listInstallationRepositories(ctx)
+	} else {
+		repos, err = listPages(ctx, c, "ListRepositories", 0, func(page github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			return c.gh.Repositories.List(ctx, "", &github.RepositoryListOptions{
+				Affiliation: "owner,organization_member",
+				Sort:        "updated",
+				ListOptions: page,
+			})
+		})
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	var allRepos []entity.Repository
-	for {
-		var repos []*github.Repository
-		var resp *github.Response
-
-		err := c.retryer.Do(ctx, "ListRepositories", func() error {
-			var err error
-			repos, resp, err = c.gh.Repositories.List(ctx, "", opts)
-			return err
-		})
-		if err != nil {
-			return nil, err
+	for _, r := range repos {
+		if !includeArchived && r.GetArchived() {
+			continue
 		}
-
-		for _, r := range repos {
-			if !includeArchived && r.GetArchived() {
-				continue
-			}
-			if filter != "" && !strings.Contains(strings.ToLower(r.GetFullName()), strings.ToLower(filter)) {
-				continue
-			}
-
-			allRepos = append(allRepos, toRepository(r))
+		if filter != "" && !strings.Contains(strings.ToLower(r.GetFullName()), strings.ToLower(filter)) {
+			continue
 		}
 
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
+		allRepos = append(allRepos, toRepository(r))
 	}
 
 	c.logger.Debug("listed repositories", "count", len(allRepos))
 	return allRepos, nil
 }
 
-func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*entity.Repository, error) {
-	c.rateLimiter.Wait()
+// listInstallationRepositories lists the repositories of every installation
+// of the app, most recently updated first like the user listing.
+func (c *Client) listInstallationRepositories(ctx context.Context) ([]*github.Repository, error) {
+	installations, err := c.app.installationIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
 
+	var all []*github.Repository
+	for _, id := range installations {
+		ctx := withInstallation(ctx, id)
+		repos, err := listPages(ctx, c, "ListInstallationRepositories", 0, func(page github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			list, resp, err := c.gh.Apps.ListRepos(ctx, &page)
+			if err != nil {
+				return nil, resp, err
+			}
+			return list.Repositories, resp, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, repos...)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].GetUpdatedAt().After(all[j].GetUpdatedAt().Time)
+	})
+	return all, nil
+}
+
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*entity.Repository, error) {
 	var r *github.Repository
-	err := c.retryer.Do(ctx, "GetRepository", func() error {
+	err := c.do(ctx, "GetRepository", idempotent, func() error {
 		var err error
 		r, _, err = c.gh.Repositories.Get(ctx, owner, repo)
 		return err
@@ -175,14 +274,14 @@ func (c *Client) ListPullRequests(ctx context.Context, filter entity.PRFilter) (
 }
 
 func (c *Client) listRepoPRs(ctx context.Context, owner, repo, state string, limit int) ([]entity.PullRequest, error) {
-	opts := &github.PullRequestListOptions{
-		State:       state,
-		Sort:        "updated",
-		Direction:   "desc",
-		ListOptions: github.ListOptions{PerPage: limit},
-	}
-
-	prs, _, err := c.gh.PullRequests.List(ctx, owner, repo, opts)
+	prs, err := listPages(ctx, c, "ListPullRequests", limit, func(page github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+		return c.gh.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+			State:       state,
+			Sort:        "updated",
+			Direction:   "desc",
+			ListOptions: page,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -196,7 +295,12 @@ func (c *Client) listRepoPRs(ctx context.Context, owner, repo, state string, lim
 }
 
 func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*entity.PullRequest, error) {
-	pr, _, err := c.gh.PullRequests.Get(ctx, owner, repo, number)
+	var pr *github.PullRequest
+	err := c.do(ctx, "GetPullRequest", idempotent, func() error {
+		var err error
+		pr, _, err = c.gh.PullRequests.Get(ctx, owner, repo, number)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -205,8 +309,6 @@ func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number
 }
 
 func (c *Client) CreatePullRequest(ctx context.Context, owner, repo, title, body, head, base string, draft bool) (*entity.PullRequest, error) {
-	c.rateLimiter.Wait()
-
 	newPR := &github.NewPullRequest{
 		Title: &title,
 		Body:  &body,
@@ -216,7 +318,7 @@ func (c *Client) CreatePullRequest(ctx context.Context, owner, repo, title, body
 	}
 
 	var pr *github.PullRequest
-	err := c.retryer.Do(ctx, "CreatePullRequest", func() error {
+	err := c.do(ctx, "CreatePullRequest", notIdempotent, func() error {
 		var err error
 		pr, _, err = c.gh.PullRequests.Create(ctx, owner, repo, newPR)
 		return err
@@ -285,15 +387,16 @@ func (c *Client) ListCommits(ctx context.Context, filter entity.CommitFilter) ([
 }
 
 func (c *Client) listRepoCommits(ctx context.Context, owner, repo, branch string, since *time.Time, limit int) ([]entity.Commit, error) {
-	opts := &github.CommitsListOptions{
-		SHA:         branch,
-		ListOptions: github.ListOptions{PerPage: limit},
-	}
-	if since != nil {
-		opts.Since = *since
-	}
-
-	commits, _, err := c.gh.Repositories.ListCommits(ctx, owner, repo, opts)
+	commits, err := listPages(ctx, c, "ListCommits", limit, func(page github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+		opts := &github.CommitsListOptions{
+			SHA:         branch,
+			ListOptions: page,
+		}
+		if since != nil {
+			opts.Since = *since
+		}
+		return c.gh.Repositories.ListCommits(ctx, owner, repo, opts)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -306,6 +409,21 @@ func (c *Client) listRepoCommits(ctx context.Context, owner, repo, branch string
 	return result, nil
 }
 
+func (c *Client) GetCommit(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+	var commit *github.RepositoryCommit
+	err := c.do(ctx, "GetCommit", idempotent, func() error {
+		var err error
+		commit, _, err = c.gh.Repositories.GetCommit(ctx, owner, repo, sha, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toCommit(commit, owner+"/"+repo, "")
+	return &result, nil
+}
+
 func (c *Client) ListWorkflowRuns(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
 	limit := 10
 	if filter.Limit > 0 {
@@ -317,97 +435,180 @@ func (c *Client) ListWorkflowRuns(ctx context.Context, filter entity.CIFilter) (
 		return nil, nil
 	}
 
-	opts := &github.ListWorkflowRunsOptions{
-		Branch:      filter.Branch,
-		ListOptions: github.ListOptions{PerPage: limit},
-	}
+	runs, err := listPages(ctx, c, "ListWorkflowRuns", limit, func(page github.ListOptions) ([]*github.WorkflowRun, *github.Response, error) {
+		opts := &github.ListWorkflowRunsOptions{
+			Branch:      filter.Branch,
+			ListOptions: page,
+		}
 
-	var runs *github.WorkflowRuns
-	var err error
+		var runs *github.WorkflowRuns
+		var resp *github.Response
+		var err error
+		if filter.Workflow != "" {
+			runs, resp, err = c.gh.Actions.ListWorkflowRunsByFileName(ctx, parts[0], parts[1], filter.Workflow, opts)
+		} else {
+			runs, resp, err = c.gh.Actions.ListRepositoryWorkflowRuns(ctx, parts[0], parts[1], opts)
+		}
+		if err != nil {
+			return nil, resp, err
+		}
+		return runs.WorkflowRuns, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	if filter.Workflow != "" {
-		runs, _, err = c.gh.Actions.ListWorkflowRunsByFileName(ctx, parts[0], parts[1], filter.Workflow, opts)
-	} else {
-		runs, _, err = c.gh.Actions.ListRepositoryWorkflowRuns(ctx, parts[0], parts[1], opts)
+	var result []entity.WorkflowRun
+	for _, run := range runs {
+		result = append(result, c.toWorkflowRun(run, filter.Repository))
 	}
 
+	return result, nil
+}
+
+func (c *Client) GetWorkflowRun(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+	var run *github.WorkflowRun
+	err := c.do(ctx, "GetWorkflowRun", idempotent, func() error {
+		var err error
+		run, _, err = c.gh.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var result []entity.WorkflowRun
-	for _, run := range runs.WorkflowRuns {
-		result = append(result, toWorkflowRun(run, filter.Repository))
+	result := c.toWorkflowRun(run, owner+"/"+repo)
+	return &result, nil
+}
+
+// GetWorkflow accepts either a numeric workflow ID or a workflow file name.
+func (c *Client) GetWorkflow(ctx context.Context, owner, repo, workflowID string) (*entity.Workflow, error) {
+	var workflow *github.Workflow
+	err := c.do(ctx, "GetWorkflow", idempotent, func() error {
+		var err error
+		if id, parseErr := strconv.ParseInt(workflowID, 10, 64); parseErr == nil {
+			workflow, _, err = c.gh.Actions.GetWorkflowByID(ctx, owner, repo, id)
+		} else {
+			workflow, _, err = c.gh.Actions.GetWorkflowByFileName(ctx, owner, repo, workflowID)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return &entity.Workflow{
+		ID:        workflow.GetID(),
+		Name:      workflow.GetName(),
+		Path:      workflow.GetPath(),
+		State:     workflow.GetState(),
+		HTMLURL:   workflow.GetHTMLURL(),
+		CreatedAt: workflow.GetCreatedAt().Time,
+		UpdatedAt: workflow.GetUpdatedAt().Time,
+	}, nil
 }
 
 func (c *Client) RerunWorkflow(ctx context.Context, owner, repo string, runID int64) error {
-	_, err := c.gh.Actions.RerunWorkflowByID(ctx, owner, repo, runID)
-	return err
+	// A lost response may hide a rerun that already started, so only
+	// rejected requests are retried.
+	return c.do(ctx, "RerunWorkflow", notIdempotent, func() error {
+		_, err := c.gh.Actions.RerunWorkflowByID(ctx, owner, repo, runID)
+		return err
+	})
 }
 
-func (c *Client) TriggerWorkflow(ctx context.Context, owner, repo, workflowID, ref string) error {
+func (c *Client) TriggerWorkflow(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error {
 	event := github.CreateWorkflowDispatchEventRequest{
 		Ref: ref,
 	}
-	_, err := c.gh.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, workflowID, event)
-	return err
+	if len(inputs) > 0 {
+		event.Inputs = make(map[string]interface{}, len(inputs))
+		for k, v := range inputs {
+			event.Inputs[k] = v
+		}
+	}
+
+	err := c.do(ctx, "TriggerWorkflow", notIdempotent, func() error {
+		var err error
+		if id, parseErr := strconv.ParseInt(workflowID, 10, 64); parseErr == nil {
+			_, err = c.gh.Actions.CreateWorkflowDispatchEventByID(ctx, owner, repo, id, event)
+		} else {
+			_, err = c.gh.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, workflowID, event)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("dispatched workflow",
+		"repo", owner+"/"+repo,
+		"workflow", workflowID,
+		"ref", ref,
+		"inputs", len(inputs),
+	)
+
+	return nil
 }
 
+// CompareBranches pages through the commits between base and head, up to
+// maxComparisonCommits, or only the first page with
+// port.WithComparisonFirstPage. Files come from the first page; GitHub lists
+// at most maxComparisonFiles of them, which sets FilesTruncated. Stopping
+// short of every commit sets CommitsTruncated.
 func (c *Client) CompareBranches(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
-	c.rateLimiter.Wait()
+	limit := maxComparisonCommits
+	if port.ComparisonFirstPage(ctx) {
+		limit = maxPerPage
+	}
 
 	var comparison *github.CommitsComparison
-	err := c.retryer.Do(ctx, "CompareBranches", func() error {
-		var err error
-		comparison, _, err = c.gh.Repositories.CompareCommits(ctx, owner, repo, base, head, nil)
-		return err
+	pages, err := listPages(ctx, c, "CompareBranches", limit, func(page github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+		result, resp, err := c.gh.Repositories.CompareCommits(ctx, owner, repo, base, head, &page)
+		if err != nil {
+			return nil, resp, err
+		}
+		if comparison == nil {
+			comparison = result
+		}
+		return result.Commits, resp, nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	var commits []entity.Commit
-	for _, commit := range comparison.Commits {
+	for _, commit := range pages {
 		commits = append(commits, toCommit(commit, owner+"/"+repo, head))
 	}
 
 	var files []entity.ChangedFile
 	for _, file := range comparison.Files {
-		files = append(files, entity.ChangedFile{
-			Filename:  file.GetFilename(),
-			Status:    file.GetStatus(),
-			Additions: file.GetAdditions(),
-			Deletions: file.GetDeletions(),
-			Changes:   file.GetChanges(),
-			Patch:     file.GetPatch(),
-		})
+		files = append(files, toChangedFile(file))
 	}
 
 	return &entity.BranchComparison{
-		Repository:   owner + "/" + repo,
-		ProdBranch:   base,
-		DevBranch:    head,
-		AheadBy:      comparison.GetAheadBy(),
-		BehindBy:     comparison.GetBehindBy(),
-		TotalCommits: comparison.GetTotalCommits(),
-		GitHubStatus: comparison.GetStatus(),
-		Commits:      commits,
-		Files:        files,
+		Repository:       owner + "/" + repo,
+		ProdBranch:       base,
+		DevBranch:        head,
+		AheadBy:          comparison.GetAheadBy(),
+		BehindBy:         comparison.GetBehindBy(),
+		TotalCommits:     comparison.GetTotalCommits(),
+		GitHubStatus:     comparison.GetStatus(),
+		Commits:          commits,
+		Files:            files,
+		FilesTruncated:   len(files) >= maxComparisonFiles,
+		CommitsTruncated: len(commits) < comparison.GetTotalCommits(),
 	}, nil
 }
 
 func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, number int, method, commitTitle string) (*entity.MergeResult, error) {
-	c.rateLimiter.Wait()
-
 	opts := &github.PullRequestOptions{
 		MergeMethod: method,
 	}
 
 	var result *github.PullRequestMergeResult
-	err := c.retryer.Do(ctx, "MergePullRequest", func() error {
+	err := c.do(ctx, "MergePullRequest", notIdempotent, func() error {
 		var err error
 		result, _, err = c.gh.PullRequests.Merge(ctx, owner, repo, number, commitTitle, opts)
 		return err
@@ -426,17 +627,48 @@ func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, numbe
 		Success:     result.GetMerged(),
 		SHA:         result.GetSHA(),
 		Message:     result.GetMessage(),
-		PRURL:       fmt.Sprintf("https://github.com/%s/%s/pull/%d", owner, repo, number),
+		PRURL:       c.links.pullRequest(owner, repo, number),
 		PRNumber:    number,
 		MergeMethod: entity.MergeMethod(method),
 	}, nil
 }
 
-func (c *Client) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
-	c.rateLimiter.Wait()
+func (c *Client) CreateBranch(ctx context.Context, owner, repo, branch, fromBranch string) error {
+	var from *github.Reference
+	err := c.do(ctx, "GetRef", idempotent, func() error {
+		var err error
+		from, _, err = c.gh.Git.GetRef(ctx, owner, repo, "heads/"+fromBranch)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve branch '%s': %w", fromBranch, err)
+	}
 
+	ref := &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: from.Object.SHA},
+	}
+	err = c.do(ctx, "CreateBranch", notIdempotent, func() error {
+		_, _, err := c.gh.Git.CreateRef(ctx, owner, repo, ref)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("created branch",
+		"repo", owner+"/"+repo,
+		"branch", branch,
+		"from", fromBranch,
+		"sha", from.Object.GetSHA(),
+	)
+
+	return nil
+}
+
+func (c *Client) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
 	ref := "refs/heads/" + branch
-	err := c.retryer.Do(ctx, "DeleteBranch", func() error {
+	err := c.do(ctx, "DeleteBranch", idempotent, func() error {
 		_, err := c.gh.Git.DeleteRef(ctx, owner, repo, ref)
 		return err
 	})
@@ -452,14 +684,48 @@ func (c *Client) DeleteBranch(ctx context.Context, owner, repo, branch string) e
 	return nil
 }
 
+// GetCurrentUser returns the login requests are made as. A GitHub App acts as
+// its bot user.
 func (c *Client) GetCurrentUser(ctx context.Context) (string, error) {
-	user, _, err := c.gh.Users.Get(ctx, "")
+	if c.app != nil {
+		var slug string
+		err := c.do(ctx, "GetApp", idempotent, func() error {
+			var err error
+			slug, err = c.app.slug(ctx)
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+		return slug + "[bot]", nil
+	}
+
+	var user *github.User
+	err := c.do(ctx, "GetCurrentUser", idempotent, func() error {
+		var err error
+		user, _, err = c.gh.Users.Get(ctx, "")
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
 	return user.GetLogin(), nil
 }
 
+// RateLimitStatus implements port.APIStatusProvider.
+func (c *Client) RateLimitStatus() entity.RateLimitStatus {
+	return entity.RateLimitStatus{
+		Remaining: c.rateLimiter.Remaining(),
+		ResetAt:   c.rateLimiter.ResetTime(),
+		Threshold: c.rateLimiter.Threshold(),
+	}
+}
+
+// RetryStats implements port.APIStatusProvider.
+func (c *Client) RetryStats() entity.RetryStats {
+	return c.retryer.Stats()
+}
+
 func toRepository(r *github.Repository) entity.Repository {
 	return entity.Repository{
 		ID:            r.GetID(),
@@ -530,6 +796,16 @@ func toCommit(c *github.RepositoryCommit, repo, branch string) entity.Commit {
 	commit := c.GetCommit()
 	author := commit.GetAuthor()
 
+	var parents []string
+	for _, p := range c.Parents {
+		parents = append(parents, p.GetSHA())
+	}
+
+	var files []entity.ChangedFile
+	for _, f := range c.Files {
+		files = append(files, toChangedFile(f))
+	}
+
 	return entity.Commit{
 		SHA:         c.GetSHA(),
 		Message:     commit.GetMessage(),
@@ -541,10 +817,31 @@ func toCommit(c *github.RepositoryCommit, repo, branch string) entity.Commit {
 		Deletions:   c.GetStats().GetDeletions(),
 		Repository:  repo,
 		Branch:      branch,
+		Parents:     parents,
+		Files:       files,
+	}
+}
+
+func toChangedFile(f *github.CommitFile) entity.ChangedFile {
+	return entity.ChangedFile{
+		Filename:         f.GetFilename(),
+		PreviousFilename: f.GetPreviousFilename(),
+		Status:           f.GetStatus(),
+		Additions:        f.GetAdditions(),
+		Deletions:        f.GetDeletions(),
+		Changes:          f.GetChanges(),
+		Patch:            f.GetPatch(),
 	}
 }
 
-func toWorkflowRun(run *github.WorkflowRun, repo string) entity.WorkflowRun {
+// toWorkflowRun converts a run, linking to it on the web interface when the
+// API left its URL out.
+func (c *Client) toWorkflowRun(run *github.WorkflowRun, repo string) entity.WorkflowRun {
+	htmlURL := run.GetHTMLURL()
+	if htmlURL == "" {
+		htmlURL = c.links.workflowRun(repo, run.GetID())
+	}
+
 	return entity.WorkflowRun{
 		ID:           run.GetID(),
 		Name:         run.GetName(),
@@ -554,9 +851,10 @@ func toWorkflowRun(run *github.WorkflowRun, repo string) entity.WorkflowRun {
 		HeadSHA:      run.GetHeadSHA(),
 		Status:       run.GetStatus(),
 		Conclusion:   run.GetConclusion(),
-		HTMLURL:      run.GetHTMLURL(),
+		HTMLURL:      htmlURL,
 		RunNumber:    run.GetRunNumber(),
 		RunAttempt:   run.GetRunAttempt(),
+		RunStartedAt: run.GetRunStartedAt().Time,
 		CreatedAt:    run.GetCreatedAt().Time,
 		UpdatedAt:    run.GetUpdatedAt().Time,
 		Repository:   repo,
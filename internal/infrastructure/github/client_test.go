@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// newStatusClient creates a client whose requests get status until the
+// server has failed failures times, and 204 afterwards. It returns the
+// request counter.
+func newStatusClient(t *testing.T, status, failures int) (*Client, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if int(requests.Add(1)) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, server.URL)
+	client.retryer = NewRetryer(RetryConfig{
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+	}, logging.NewNoop())
+
+	return client, &requests
+}
+
+func TestClient_RetriesByIdempotency(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		call         func(*Client) error
+		wantErr      bool
+		wantRequests int32
+	}{
+		{
+			name:   "idempotent retried after server error",
+			status: http.StatusBadGateway,
+			call: func(c *Client) error {
+				return c.DeleteBranch(context.Background(), "acme", "api", "feature")
+			},
+			wantRequests: 2,
+		},
+		{
+			name:   "dispatch not retried after server error",
+			status: http.StatusBadGateway,
+			call: func(c *Client) error {
+				return c.TriggerWorkflow(context.Background(), "acme", "api", "rollback.yml", "main", nil)
+			},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:   "rerun not retried after server error",
+			status: http.StatusServiceUnavailable,
+			call: func(c *Client) error {
+				return c.RerunWorkflow(context.Background(), "acme", "api", 1)
+			},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:   "dispatch retried after rejection",
+			status: http.StatusTooManyRequests,
+			call: func(c *Client) error {
+				return c.TriggerWorkflow(context.Background(), "acme", "api", "rollback.yml", "main", nil)
+			},
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newStatusClient(t, tt.status, 1)
+
+			err := tt.call(client)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
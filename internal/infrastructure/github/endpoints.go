@@ -0,0 +1,118 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Endpoints holds the base URLs of a GitHub instance. The client sends its
+// API requests to them and builds every web link from them, so pointing them
+// at a GitHub Enterprise Server (or a test server) moves everything.
+type Endpoints struct {
+	APIURL    string // REST API root, e.g. https://ghe.example.com/api/v3/
+	UploadURL string // Upload API root, e.g. https://ghe.example.com/api/uploads/
+	WebURL    string // Web interface root, e.g. https://ghe.example.com/
+}
+
+const (
+	defaultAPIURL    = "https://api.github.com/"
+	defaultUploadURL = "https://uploads.github.com/"
+	defaultWebURL    = "https://github.com/"
+
+	// Paths of the APIs on a GitHub Enterprise Server host.
+	enterpriseAPIPath    = "/api/v3"
+	enterpriseUploadPath = "/api/uploads"
+)
+
+// DefaultEndpoints returns the endpoints of github.com.
+func DefaultEndpoints() Endpoints {
+	return Endpoints{
+		APIURL:    defaultAPIURL,
+		UploadURL: defaultUploadURL,
+		WebURL:    defaultWebURL,
+	}
+}
+
+// withDefaults fills in the URLs left empty. On github.com they are the
+// public endpoints; on an Enterprise Server the API and upload URLs derive
+// from the web URL and back, following the /api/v3 and /api/uploads layout.
+func (e Endpoints) withDefaults() Endpoints {
+	if e.APIURL == "" && e.WebURL == "" {
+		e.APIURL = defaultAPIURL
+	}
+
+	if e.APIURL == "" {
+		if sameURL(e.WebURL, defaultWebURL) {
+			e.APIURL = defaultAPIURL
+		} else {
+			e.APIURL = strings.TrimSuffix(e.WebURL, "/") + enterpriseAPIPath
+		}
+	}
+
+	apiRoot, onEnterprise := strings.CutSuffix(strings.TrimSuffix(e.APIURL, "/"), enterpriseAPIPath)
+
+	if e.UploadURL == "" {
+		switch {
+		case sameURL(e.APIURL, defaultAPIURL):
+			e.UploadURL = defaultUploadURL
+		case onEnterprise:
+			e.UploadURL = apiRoot + enterpriseUploadPath
+		default:
+			e.UploadURL = e.APIURL
+		}
+	}
+
+	if e.WebURL == "" {
+		switch {
+		case sameURL(e.APIURL, defaultAPIURL):
+			e.WebURL = defaultWebURL
+		case onEnterprise:
+			e.WebURL = apiRoot
+		default:
+			e.WebURL = e.APIURL
+		}
+	}
+
+	return e
+}
+
+// sameURL compares two URLs ignoring a trailing slash.
+func sameURL(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
+// parseBaseURL parses a base URL, adding the trailing slash go-github needs
+// to resolve paths against it.
+func parseBaseURL(name, raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s URL %q: %w", name, raw, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid %s URL %q: expected an absolute http(s) URL", name, raw)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
+
+// webLinks builds links into the web interface. It is the only place the
+// client constructs URLs itself; everything else comes from the API.
+type webLinks struct {
+	base *url.URL
+}
+
+func (l webLinks) url(elem ...string) string {
+	return l.base.JoinPath(elem...).String()
+}
+
+func (l webLinks) pullRequest(owner, repo string, number int) string {
+	return l.url(owner, repo, "pull", strconv.Itoa(number))
+}
+
+func (l webLinks) workflowRun(repository string, runID int64) string {
+	return l.url(repository, "actions", "runs", strconv.FormatInt(runID, 10))
+}
@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+func TestEndpoints_WithDefaults(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints Endpoints
+		want      Endpoints
+	}{
+		{
+			name:      "empty is github.com",
+			endpoints: Endpoints{},
+			want:      DefaultEndpoints(),
+		},
+		{
+			name:      "github.com web URL",
+			endpoints: Endpoints{WebURL: "https://github.com"},
+			want: Endpoints{
+				APIURL:    defaultAPIURL,
+				UploadURL: defaultUploadURL,
+				WebURL:    "https://github.com",
+			},
+		},
+		{
+			name:      "enterprise from web URL",
+			endpoints: Endpoints{WebURL: "https://ghe.example.com/"},
+			want: Endpoints{
+				APIURL:    "https://ghe.example.com/api/v3",
+				UploadURL: "https://ghe.example.com/api/uploads",
+				WebURL:    "https://ghe.example.com/",
+			},
+		},
+		{
+			name:      "enterprise from API URL",
+			endpoints: Endpoints{APIURL: "https://ghe.example.com/api/v3/"},
+			want: Endpoints{
+				APIURL:    "https://ghe.example.com/api/v3/",
+				UploadURL: "https://ghe.example.com/api/uploads",
+				WebURL:    "https://ghe.example.com",
+			},
+		},
+		{
+			name: "explicit URLs kept",
+			endpoints: Endpoints{
+				APIURL:    "https://api.ghe.example.com/",
+				UploadURL: "https://uploads.ghe.example.com/",
+				WebURL:    "https://ghe.example.com/",
+			},
+			want: Endpoints{
+				APIURL:    "https://api.ghe.example.com/",
+				UploadURL: "https://uploads.ghe.example.com/",
+				WebURL:    "https://ghe.example.com/",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.endpoints.withDefaults(); got != tt.want {
+				t.Errorf("withDefaults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClient_RejectsInvalidURL(t *testing.T) {
+	_, err := NewClient(Credentials{Token: "token"}, Endpoints{WebURL: "ghe.example.com"}, nil, logging.NewNoop())
+	if err == nil {
+		t.Fatal("NewClient() error = nil, want invalid URL error")
+	}
+}
+
+func TestClient_UsesEnterpriseEndpoints(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/repos/acme/api/pulls/7/merge":
+			w.Write([]byte(`{"merged":true,"sha":"abc"}`))
+		case "/api/v3/repos/acme/api/actions/runs/42":
+			w.Write([]byte(`{"id":42,"status":"completed"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(Credentials{Token: "token"}, Endpoints{WebURL: server.URL}, nil, logging.NewNoop())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	merge, err := client.MergePullRequest(context.Background(), "acme", "api", 7, "merge", "")
+	if err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+	if want := server.URL + "/acme/api/pull/7"; merge.PRURL != want {
+		t.Errorf("PRURL = %s, want %s", merge.PRURL, want)
+	}
+
+	run, err := client.GetWorkflowRun(context.Background(), "acme", "api", 42)
+	if err != nil {
+		t.Fatalf("GetWorkflowRun: %v", err)
+	}
+	if want := server.URL + "/acme/api/actions/runs/42"; run.HTMLURL != want {
+		t.Errorf("HTMLURL = %s, want %s", run.HTMLURL, want)
+	}
+
+	if len(paths) != 2 {
+		t.Errorf("requests = %v, want 2 under /api/v3", paths)
+	}
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/google/go-github/v60/github"
+)
+
+// translateError wraps GitHub API errors with the matching port error. The
+// original error stays in the chain, so errors.As on go-github types still
+// works. Errors that match no port error are returned unchanged.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// Raised by the rate limiter before the request was sent; drop the
+	// transport's URL wrapping.
+	var retryLaterErr *port.RetryLaterError
+	if errors.As(err, &retryLaterErr) {
+		return retryLaterErr
+	}
+
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &rateLimitErr) || errors.As(err, &abuseErr) {
+		return fmt.Errorf("%w: %w", port.ErrRateLimited, err)
+	}
+
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil {
+		return err
+	}
+
+	switch ghErr.Response.StatusCode {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w: %w", port.ErrUnauthorized, err)
+	case http.StatusForbidden:
+		// Secondary rate limits come back as 403 without a RateLimitError.
+		if strings.Contains(strings.ToLower(ghErr.Message), "rate limit") {
+			return fmt.Errorf("%w: %w", port.ErrRateLimited, err)
+		}
+		return fmt.Errorf("%w: %w", port.ErrUnauthorized, err)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %w", port.ErrNotFound, err)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %w", port.ErrRateLimited, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,168 @@
+package github
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/cache"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// validatorTTL is how long a stored response can be revalidated. GitHub
+// answers 304 for as long as the resource is unchanged, so this only bounds
+// how long unused entries occupy the cache.
+const validatorTTL = 24 * time.Hour
+
+// responseKeyPrefix starts the cache key of every stored response.
+const responseKeyPrefix = "http:"
+
+// responseRepoKeyPrefix starts the keys of the stored responses for a
+// repository's endpoints, so they can be dropped with its other entries.
+// GitHub matches repository names case-insensitively, and so does the key.
+func responseRepoKeyPrefix(repository string) string {
+	return responseKeyPrefix + "repos/" + strings.ToLower(repository) + ":"
+}
+
+func init() {
+	cache.Register(&storedResponse{})
+}
+
+// storedResponse is a GET response kept for revalidation.
+type storedResponse struct {
+	ETag         string
+	LastModified string
+	StatusCode   int
+	Header       http.Header
+	Body         []byte
+}
+
+// etagTransport makes GET requests conditional. It stores the validators and
+// body of successful responses and sends If-None-Match/If-Modified-Since on
+// the next request for the same URL. A 304 is answered with the stored body,
+// and since GitHub does not count 304s against X-RateLimit-Remaining, polling
+// unchanged resources is free.
+type etagTransport struct {
+	base   http.RoundTripper
+	store  cache.Store
+	scope  string // Account name; responses differ per token
+	logger *logging.Logger
+}
+
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	key := t.responseKey(req)
+
+	var stored *storedResponse
+	if v, ok := t.store.Get(key); ok {
+		stored, _ = v.(*storedResponse)
+	}
+
+	if stored != nil {
+		req = req.Clone(req.Context())
+		if stored.ETag != "" {
+			req.Header.Set("If-None-Match", stored.ETag)
+		}
+		if stored.LastModified != "" {
+			req.Header.Set("If-Modified-Since", stored.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && stored != nil {
+		t.logger.Debug("not modified, serving stored response", "url", req.URL.Path)
+		resp.Body.Close()
+		return stored.response(req, resp), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.store.SetWithTTL(key, &storedResponse{
+		ETag:         etag,
+		LastModified: lastModified,
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+	}, validatorTTL)
+
+	return resp, nil
+}
+
+// responseKey identifies the stored response for req. Responses differ per
+// token, so the key names the account and, for requests pinned to a GitHub
+// App installation such as GET /installation/repositories, the installation.
+// Requests under /repos/{owner}/{repo} are keyed by that repository first.
+func (t *etagTransport) responseKey(req *http.Request) string {
+	identity := t.scope
+	if installationID, ok := req.Context().Value(installationKey{}).(int64); ok {
+		identity += "/installation-" + strconv.FormatInt(installationID, 10)
+	}
+
+	prefix := responseKeyPrefix
+	if repository, ok := repositoryOf(req.URL.Path); ok {
+		prefix = responseRepoKeyPrefix(repository)
+	}
+	return prefix + identity + ":" + req.Header.Get("Accept") + ":" + req.URL.String()
+}
+
+// repositoryOf returns "owner/repo" for a path under /repos/{owner}/{repo},
+// after any API prefix such as /api/v3 on GitHub Enterprise Server.
+func repositoryOf(path string) (string, bool) {
+	i := strings.Index(path, "/repos/")
+	if i < 0 {
+		return "", false
+	}
+	parts := strings.SplitN(path[i+len("/repos/"):], "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	return parts[0] + "/" + parts[1], true
+}
+
+// response rebuilds the stored response for req. Rate limit headers are
+// taken from the 304, which reflects the current budget.
+func (s *storedResponse) response(req *http.Request, notModified *http.Response) *http.Response {
+	header := s.Header.Clone()
+	for _, name := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-RateLimit-Used", "X-RateLimit-Resource", "Date"} {
+		if v := notModified.Header.Get(name); v != "" {
+			header.Set(name, v)
+		}
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(s.StatusCode),
+		StatusCode:    s.StatusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(s.Body)),
+		ContentLength: int64(len(s.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/cache"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+const lastModified = "Wed, 01 May 2024 10:00:00 GMT"
+
+// newConditionalServer serves a repository with validators. Requests that
+// carry both validators get a 304 with a lower remaining rate limit. It
+// returns the number of 304s sent.
+func newConditionalServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Reset", "1893456000")
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") == lastModified {
+			notModified.Add(1)
+			w.Header().Set("X-RateLimit-Remaining", "4321")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("If-None-Match = %q, want \"v1\" with If-Modified-Since", r.Header.Get("If-None-Match"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", lastModified)
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Write([]byte(`{"name":"api","full_name":"acme/api","default_branch":"main"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &notModified
+}
+
+func TestETagTransport_ServesStoredBodyOnNotModified(t *testing.T) {
+	server, notModified := newConditionalServer(t)
+	store := cache.New(cache.DefaultConfig())
+	defer store.Stop()
+
+	client := &http.Client{Transport: &etagTransport{
+		base:   http.DefaultTransport,
+		store:  store,
+		scope:  "acme",
+		logger: logging.NewNoop(),
+	}}
+
+	var bodies []string
+	var resp *http.Response
+	for i := 0; i < 2; i++ {
+		var err error
+		resp, err = client.Get(server.URL + "/repos/acme/api")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		bodies = append(bodies, string(body))
+	}
+
+	if notModified.Load() != 1 {
+		t.Fatalf("server sent %d 304s, want 1 for the conditional request", notModified.Load())
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200 rebuilt from the stored response", resp.StatusCode)
+	}
+	if bodies[1] != bodies[0] {
+		t.Errorf("body = %q, want stored body %q", bodies[1], bodies[0])
+	}
+	if got := resp.Header.Get("X-RateLimit-Remaining"); got != "4321" {
+		t.Errorf("X-RateLimit-Remaining = %q, want 4321 from the 304", got)
+	}
+	if got := resp.Header.Get("ETag"); got != `"v1"` {
+		t.Errorf("ETag = %q, want stored \"v1\"", got)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestETagTransport_KeysResponsesByInstallation(t *testing.T) {
+	// Each installation sees its own repositories under the same URL.
+	var notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + r.Header.Get("X-Installation") + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(`{"installation":` + etag + `}`))
+	}))
+	t.Cleanup(server.Close)
+
+	store := cache.New(cache.DefaultConfig())
+	defer store.Stop()
+
+	// Stands in for installationTransport, which picks the token from ctx.
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		id, _ := req.Context().Value(installationKey{}).(int64)
+		req = req.Clone(req.Context())
+		req.Header.Set("X-Installation", strconv.FormatInt(id, 10))
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client := &http.Client{Transport: &etagTransport{base: base, store: store, scope: "app", logger: logging.NewNoop()}}
+
+	get := func(installationID int64) string {
+		req, _ := http.NewRequestWithContext(withInstallation(context.Background(), installationID), http.MethodGet, server.URL+"/installation/repositories", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	for _, id := range []int64{1, 2, 1, 2} {
+		if got, want := get(id), `{"installation":"`+strconv.FormatInt(id, 10)+`"}`; got != want {
+			t.Errorf("installation %d body = %s, want %s", id, got, want)
+		}
+	}
+	if notModified.Load() != 2 {
+		t.Errorf("server sent %d 304s, want 2 once each installation revalidates its own response", notModified.Load())
+	}
+}
+
+func TestClient_ConditionalRequestUpdatesRateLimit(t *testing.T) {
+	server, notModified := newConditionalServer(t)
+	store := cache.New(cache.DefaultConfig())
+	defer store.Stop()
+
+	client, err := NewClient(Credentials{Token: "token"}, Endpoints{APIURL: server.URL, WebURL: server.URL}, store, logging.NewNoop())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		repo, err := client.GetRepository(context.Background(), "acme", "api")
+		if err != nil {
+			t.Fatalf("GetRepository: %v", err)
+		}
+		if repo.FullName != "acme/api" || repo.DefaultBranch != "main" {
+			t.Errorf("GetRepository() = %+v, want acme/api on main", repo)
+		}
+	}
+
+	if notModified.Load() != 1 {
+		t.Errorf("server sent %d 304s, want 1", notModified.Load())
+	}
+	if got := client.rateLimiter.Remaining(); got != 4321 {
+		t.Errorf("Remaining() = %d, want 4321 from the 304", got)
+	}
+}
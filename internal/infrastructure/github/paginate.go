@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// maxPerPage is the largest page size the GitHub API accepts.
+const maxPerPage = 100
+
+// GitHub's compare endpoint returns at most this many changed files, with no
+// way to page through the rest.
+const maxComparisonFiles = 300
+
+// maxComparisonCommits bounds how many commits CompareBranches pages through,
+// so a comparison of distant branches stays a handful of requests.
+const maxComparisonCommits = 1000
+
+// listPages calls list for successive pages until the API reports no next
+// page or limit items were collected. A limit of 0 collects every page. Each
+// page is a separate request, retried on its own.
+func listPages[T any](ctx context.Context, c *Client, operation string, limit int, list func(opts github.ListOptions) ([]T, *github.Response, error)) ([]T, error) {
+	opts := github.ListOptions{PerPage: maxPerPage}
+	if limit > 0 && limit < maxPerPage {
+		opts.PerPage = limit
+	}
+
+	var all []T
+	for {
+		var items []T
+		var resp *github.Response
+		err := c.do(ctx, operation, idempotent, func() error {
+			var err error
+			items, resp, err = list(opts)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+		if limit > 0 && len(all) >= limit {
+			return all[:limit], nil
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+// pagedHandler serves total items, per_page at a time, with Link headers
+// pointing at the next page. item renders the i-th item as JSON.
+func pagedHandler(total int, wrap func(items string) string, item func(i int) string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if perPage == 0 {
+			perPage = 30
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+
+		start := (page - 1) * perPage
+		end := min(start+perPage, total)
+
+		var items []string
+		for i := start; i < end; i++ {
+			items = append(items, item(i))
+		}
+
+		if end < total {
+			next := *r.URL
+			q := next.Query()
+			q.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = q.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.String()))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(wrap("[" + strings.Join(items, ",") + "]")))
+	}
+}
+
+func newPagedClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return newTestClient(t, server.URL)
+}
+
+func TestClient_ListWorkflowRuns_PagesPastMaxPerPage(t *testing.T) {
+	client := newPagedClient(t, pagedHandler(250,
+		func(items string) string { return `{"total_count":250,"workflow_runs":` + items + `}` },
+		func(i int) string { return fmt.Sprintf(`{"id":%d}`, i+1) },
+	))
+
+	runs, err := client.ListWorkflowRuns(context.Background(), entity.CIFilter{Repository: "acme/api", Limit: 150})
+	if err != nil {
+		t.Fatalf("ListWorkflowRuns() error = %v", err)
+	}
+
+	if len(runs) != 150 {
+		t.Fatalf("len(runs) = %d, want 150", len(runs))
+	}
+	if runs[149].ID != 150 {
+		t.Errorf("runs[149].ID = %d, want 150", runs[149].ID)
+	}
+}
+
+func TestClient_CompareBranches_Pagination(t *testing.T) {
+	tests := []struct {
+		name                 string
+		totalCommits         int
+		files                int
+		firstPage            bool
+		wantCommits          int
+		wantFilesTruncated   bool
+		wantCommitsTruncated bool
+	}{
+		{"fits in one page", 3, 2, false, 3, false, false},
+		{"pages through commits", 260, 10, false, 260, false, false},
+		{"file limit reached", 5, maxComparisonFiles, false, 5, true, false},
+		{"commit limit reached", maxComparisonCommits + 50, 10, false, maxComparisonCommits, false, true},
+		{"first page holds every commit", 3, 2, true, 3, false, false},
+		{"first page only", 260, 10, true, maxPerPage, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var files []string
+			for i := 0; i < tt.files; i++ {
+				files = append(files, fmt.Sprintf(`{"filename":"file%d.go"}`, i))
+			}
+
+			client := newPagedClient(t, pagedHandler(tt.totalCommits,
+				func(items string) string {
+					return fmt.Sprintf(`{"status":"ahead","ahead_by":%d,"total_commits":%d,"commits":%s,"files":[%s]}`,
+						tt.totalCommits, tt.totalCommits, items, strings.Join(files, ","))
+				},
+				func(i int) string { return fmt.Sprintf(`{"sha":"sha%d"}`, i) },
+			))
+
+			ctx := context.Background()
+			if tt.firstPage {
+				ctx = port.WithComparisonFirstPage(ctx)
+			}
+
+			comparison, err := client.CompareBranches(ctx, "acme", "api", "main", "develop")
+			if err != nil {
+				t.Fatalf("CompareBranches() error = %v", err)
+			}
+
+			if len(comparison.Commits) != tt.wantCommits {
+				t.Errorf("len(Commits) = %d, want %d", len(comparison.Commits), tt.wantCommits)
+			}
+			if len(comparison.Files) != tt.files {
+				t.Errorf("len(Files) = %d, want %d", len(comparison.Files), tt.files)
+			}
+			if comparison.FilesTruncated != tt.wantFilesTruncated {
+				t.Errorf("FilesTruncated = %v, want %v", comparison.FilesTruncated, tt.wantFilesTruncated)
+			}
+			if comparison.CommitsTruncated != tt.wantCommitsTruncated {
+				t.Errorf("CommitsTruncated = %v, want %v", comparison.CommitsTruncated, tt.wantCommitsTruncated)
+			}
+		})
+	}
+}
@@ -1,102 +1,173 @@
 package github
 
 import (
+	"context"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
 	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
 )
 
-// RateLimiter tracks GitHub API rate limits and blocks requests when near the limit.
+// Rate limit buckets, as named by the X-RateLimit-Resource header.
+const (
+	resourceCore    = "core"
+	resourceSearch  = "search"
+	resourceGraphQL = "graphql"
+)
+
+// RateLimiter tracks GitHub API rate limits per resource bucket and blocks
+// requests when near the limit.
 type RateLimiter struct {
-	mu           sync.Mutex
-	remaining    int
-	resetTime    time.Time
-	threshold    int // Minimum remaining before waiting
-	logger       *logging.Logger
+	mu        sync.Mutex
+	buckets   map[string]*rateLimitBucket
+	threshold int           // Minimum remaining before waiting
+	maxWait   time.Duration // Longest wait before failing with port.RetryLaterError
+	logger    *logging.Logger
+}
+
+type rateLimitBucket struct {
+	remaining int
+	resetTime time.Time
 }
 
 // NewRateLimiter creates a new rate limiter.
-func NewRateLimiter(threshold int, logger *logging.Logger) *RateLimiter {
+func NewRateLimiter(threshold int, maxWait time.Duration, logger *logging.Logger) *RateLimiter {
 	return &RateLimiter{
-		remaining: 5000, // GitHub default
+		buckets:   make(map[string]*rateLimitBucket),
 		threshold: threshold,
+		maxWait:   maxWait,
 		logger:    logger.WithComponent("ratelimit"),
 	}
 }
 
+// resourceFor returns the rate limit bucket a request counts against.
+func resourceFor(req *http.Request) string {
+	path := strings.TrimPrefix(req.URL.Path, "/api/v3")
+	path = strings.TrimPrefix(path, "/api")
+
+	switch {
+	case strings.HasPrefix(path, "/search/"):
+		return resourceSearch
+	case path == "/graphql":
+		return resourceGraphQL
+	default:
+		return resourceCore
+	}
+}
+
 // UpdateFromResponse updates rate limit info from HTTP response headers.
 func (r *RateLimiter) UpdateFromResponse(resp *http.Response) {
 	if resp == nil {
 		return
 	}
 
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	resource := resp.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = resourceCore
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if remaining := resp.Header.Get("X-RateLimit-Remaining"); remaining != "" {
-		if val, err := strconv.Atoi(remaining); err == nil {
-			r.remaining = val
-		}
+	bucket, ok := r.buckets[resource]
+	if !ok {
+		bucket = &rateLimitBucket{}
+		r.buckets[resource] = bucket
 	}
+	bucket.remaining = remaining
 
 	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
 		if val, err := strconv.ParseInt(reset, 10, 64); err == nil {
-			r.resetTime = time.Unix(val, 0)
+			bucket.resetTime = time.Unix(val, 0)
 		}
 	}
 
-	if r.remaining <= r.threshold {
+	if bucket.remaining <= r.threshold {
 		r.logger.Warn("rate limit low",
-			"remaining", r.remaining,
-			"reset_at", r.resetTime.Format(time.RFC3339),
+			"resource", resource,
+			"remaining", bucket.remaining,
+			"reset_at", bucket.resetTime.Format(time.RFC3339),
 		)
 	}
 }
 
-// Wait blocks if rate limit is near the threshold.
-// Returns true if it had to wait.
-func (r *RateLimiter) Wait() bool {
+// Wait blocks if the resource's rate limit is near the threshold, until the
+// limit resets or ctx is done. It returns ctx.Err() when ctx ends the wait.
+// An exhausted limit that resets later than maxWait fails right away with a
+// port.RetryLaterError; otherwise waits are capped at maxWait.
+func (r *RateLimiter) Wait(ctx context.Context, resource string) error {
 	r.mu.Lock()
-	remaining := r.remaining
-	resetTime := r.resetTime
+	bucket, ok := r.buckets[resource]
+	var remaining int
+	var resetTime time.Time
+	if ok {
+		remaining, resetTime = bucket.remaining, bucket.resetTime
+	}
 	r.mu.Unlock()
 
-	if remaining > r.threshold {
-		return false
+	if !ok || remaining > r.threshold {
+		return nil
 	}
 
 	waitDuration := time.Until(resetTime)
 	if waitDuration <= 0 {
-		return false
+		return nil
 	}
 
-	// Cap wait time at 60 seconds
-	if waitDuration > 60*time.Second {
-		waitDuration = 60 * time.Second
+	if waitDuration > r.maxWait {
+		if remaining == 0 {
+			return &port.RetryLaterError{Resource: resource, RetryAt: resetTime, Err: port.ErrRateLimited}
+		}
+		waitDuration = r.maxWait
 	}
 
 	r.logger.Info("waiting for rate limit reset",
+		"resource", resource,
 		"remaining", remaining,
 		"wait_duration", waitDuration.String(),
 	)
 
-	time.Sleep(waitDuration)
-	return true
+	timer := time.NewTimer(waitDuration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
-// Remaining returns the current remaining rate limit.
+// Remaining returns the current remaining core rate limit.
 func (r *RateLimiter) Remaining() int {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.remaining
+	if bucket, ok := r.buckets[resourceCore]; ok {
+		return bucket.remaining
+	}
+	return 5000 // GitHub default
 }
 
-// ResetTime returns when the rate limit resets.
+// ResetTime returns when the core rate limit resets.
 func (r *RateLimiter) ResetTime() time.Time {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.resetTime
+	if bucket, ok := r.buckets[resourceCore]; ok {
+		return bucket.resetTime
+	}
+	return time.Time{}
+}
+
+// Threshold returns the remaining count at which Wait starts blocking.
+func (r *RateLimiter) Threshold() int {
+	return r.threshold
 }
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+func exhaustedResponse(resource string, reset time.Time) *http.Response {
+	return &http.Response{Header: http.Header{
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+		"X-Ratelimit-Resource":  []string{resource},
+	}}
+}
+
+func TestRateLimiter_WaitRespectsContext(t *testing.T) {
+	limiter := NewRateLimiter(10, 2*time.Hour, logging.NewNoop())
+	limiter.UpdateFromResponse(exhaustedResponse(resourceCore, time.Now().Add(time.Hour)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := limiter.Wait(ctx, resourceCore)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait() took %v, want it to return when the context ends", elapsed)
+	}
+}
+
+func TestRateLimiter_FailsFastPastMaxWait(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	limiter := NewRateLimiter(10, time.Minute, logging.NewNoop())
+	limiter.UpdateFromResponse(exhaustedResponse(resourceSearch, reset))
+
+	err := limiter.Wait(context.Background(), resourceSearch)
+
+	var retryLater *port.RetryLaterError
+	if !errors.As(err, &retryLater) {
+		t.Fatalf("Wait() error = %v, want RetryLaterError", err)
+	}
+	if !retryLater.RetryAt.Equal(reset) {
+		t.Errorf("RetryAt = %v, want %v", retryLater.RetryAt, reset)
+	}
+	if retryLater.Resource != resourceSearch {
+		t.Errorf("Resource = %s, want %s", retryLater.Resource, resourceSearch)
+	}
+	if !errors.Is(err, port.ErrRateLimited) {
+		t.Error("error does not wrap ErrRateLimited")
+	}
+}
+
+func TestRateLimiter_TracksBucketsPerResource(t *testing.T) {
+	limiter := NewRateLimiter(10, time.Minute, logging.NewNoop())
+	limiter.UpdateFromResponse(exhaustedResponse(resourceSearch, time.Now().Add(time.Hour)))
+
+	if err := limiter.Wait(context.Background(), resourceCore); err != nil {
+		t.Errorf("Wait(core) error = %v, want nil while only search is exhausted", err)
+	}
+	if got := limiter.Remaining(); got != 5000 {
+		t.Errorf("Remaining() = %d, want the core default 5000", got)
+	}
+}
+
+func TestResourceFor(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/repos/acme/api/pulls", resourceCore},
+		{"/repos/acme/search/pulls", resourceCore},
+		{"/search/issues", resourceSearch},
+		{"/api/v3/search/code", resourceSearch},
+		{"/graphql", resourceGraphQL},
+		{"/api/graphql", resourceGraphQL},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		if got := resourceFor(req); got != tt.want {
+			t.Errorf("resourceFor(%s) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
@@ -3,9 +3,14 @@ package github
 import (
 	"context"
 	"errors"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"time"
 
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
 	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
 	"github.com/google/go-github/v60/github"
 )
@@ -16,6 +21,8 @@ type RetryConfig struct {
 	InitialBackoff time.Duration // Initial backoff duration
 	MaxBackoff     time.Duration // Maximum backoff duration
 	Multiplier     float64       // Backoff multiplier
+	Jitter         float64       // Random extra delay, as a fraction of each delay
+	MaxWait        time.Duration // Longest wait GitHub may ask for before failing with port.RetryLaterError
 }
 
 // DefaultRetryConfig returns sensible retry defaults.
@@ -25,13 +32,18 @@ func DefaultRetryConfig() RetryConfig {
 		InitialBackoff: 1 * time.Second,
 		MaxBackoff:     30 * time.Second,
 		Multiplier:     2.0,
+		Jitter:         0.2,
+		MaxWait:        60 * time.Second,
 	}
 }
 
 // Retryer handles retry logic with exponential backoff.
 type Retryer struct {
-	config RetryConfig
-	logger *logging.Logger
+	config    RetryConfig
+	logger    *logging.Logger
+	retries   atomic.Uint64
+	recovered atomic.Uint64
+	exhausted atomic.Uint64
 }
 
 // NewRetryer creates a new retryer.
@@ -83,36 +95,104 @@ func isRetryableError(err error) bool {
 	return false
 }
 
-// Do executes a function with retry logic.
-func (r *Retryer) Do(ctx context.Context, operation string, fn func() error) error {
+// isRejectedError checks if GitHub refused a request without processing it,
+// which makes it safe to retry even when the operation is not idempotent.
+func isRejectedError(err error) bool {
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &rateLimitErr) || errors.As(err, &abuseErr) {
+		return true
+	}
+
+	var ghErr *github.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusTooManyRequests
+}
+
+// serverDelay returns how long GitHub asked to wait before retrying, from a
+// secondary rate limit's Retry-After, a primary rate limit's reset time, or
+// a Retry-After header on any other response.
+func serverDelay(err error) (time.Duration, bool) {
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) && abuseErr.RetryAfter != nil {
+		return *abuseErr.RetryAfter, true
+	}
+
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return max(time.Until(rateLimitErr.Rate.Reset.Time), 0), true
+	}
+
+	resp := errorResponse(err)
+	if resp == nil {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	return 0, false
+}
+
+// errorResponse returns the HTTP response behind a go-github error.
+func errorResponse(err error) *http.Response {
+	var abuseErr *github.AbuseRateLimitError
+	var rateLimitErr *github.RateLimitError
+	var ghErr *github.ErrorResponse
+	switch {
+	case errors.As(err, &abuseErr):
+		return abuseErr.Response
+	case errors.As(err, &rateLimitErr):
+		return rateLimitErr.Response
+	case errors.As(err, &ghErr):
+		return ghErr.Response
+	default:
+		return nil
+	}
+}
+
+// rateLimitResource returns the rate limit bucket an error response reports.
+func rateLimitResource(err error) string {
+	if resp := errorResponse(err); resp != nil {
+		if resource := resp.Header.Get("X-RateLimit-Resource"); resource != "" {
+			return resource
+		}
+	}
+	return resourceCore
+}
+
+// jitter adds a random extra delay of up to config.Jitter times d, so
+// clients that failed together do not retry together.
+func (r *Retryer) jitter(d time.Duration) time.Duration {
+	return d + time.Duration(rand.Float64()*r.config.Jitter*float64(d))
+}
+
+// Do executes a function with retry logic. Operations that are not
+// idempotent are only retried when GitHub rejected the request.
+func (r *Retryer) Do(ctx context.Context, operation string, idempotent bool, fn func() error) error {
 	var lastErr error
 	backoff := r.config.InitialBackoff
+	var delay time.Duration
 
 	for attempt := 0; attempt <= r.config.MaxRetries; attempt++ {
 		if attempt > 0 {
+			r.retries.Add(1)
 			r.logger.Info("retrying operation",
 				"operation", operation,
 				"attempt", attempt,
 				"max_retries", r.config.MaxRetries,
-				"backoff", backoff.String(),
+				"delay", delay.String(),
 			)
 
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(backoff):
-			}
-
-			// Calculate next backoff
-			backoff = time.Duration(float64(backoff) * r.config.Multiplier)
-			if backoff > r.config.MaxBackoff {
-				backoff = r.config.MaxBackoff
+			case <-time.After(delay):
 			}
 		}
 
 		err := fn()
 		if err == nil {
 			if attempt > 0 {
+				r.recovered.Add(1)
 				r.logger.Info("operation succeeded after retry",
 					"operation", operation,
 					"attempts", attempt+1,
@@ -123,13 +203,39 @@ func (r *Retryer) Do(ctx context.Context, operation string, fn func() error) err
 
 		lastErr = err
 
-		if !isRetryableError(err) {
+		retryable := isRetryableError(err)
+		if !idempotent {
+			retryable = isRejectedError(err)
+		}
+		if !retryable {
 			r.logger.Debug("error is not retryable",
 				"operation", operation,
 				"error", err.Error(),
 			)
-			return err
+			return translateError(err)
+		}
+
+		// Wait as long as GitHub asks, failing fast when that is too long;
+		// otherwise back off exponentially.
+		if wait, ok := serverDelay(err); ok {
+			if wait > r.config.MaxWait {
+				r.logger.Warn("rate limit wait exceeds ceiling",
+					"operation", operation,
+					"wait", wait.String(),
+					"max_wait", r.config.MaxWait.String(),
+				)
+				return &port.RetryLaterError{
+					Resource: rateLimitResource(err),
+					RetryAt:  time.Now().Add(wait),
+					Err:      translateError(err),
+				}
+			}
+			delay = wait
+		} else {
+			delay = backoff
+			backoff = min(time.Duration(float64(backoff)*r.config.Multiplier), r.config.MaxBackoff)
 		}
+		delay = r.jitter(delay)
 
 		r.logger.Warn("operation failed with retryable error",
 			"operation", operation,
@@ -138,11 +244,21 @@ func (r *Retryer) Do(ctx context.Context, operation string, fn func() error) err
 		)
 	}
 
+	r.exhausted.Add(1)
 	r.logger.Error("operation failed after all retries",
 		"operation", operation,
 		"attempts", r.config.MaxRetries+1,
 		"error", lastErr.Error(),
 	)
 
-	return lastErr
+	return translateError(lastErr)
+}
+
+// Stats returns the retry counters since the retryer was created.
+func (r *Retryer) Stats() entity.RetryStats {
+	return entity.RetryStats{
+		Retries:   r.retries.Load(),
+		Recovered: r.recovered.Load(),
+		Exhausted: r.exhausted.Load(),
+	}
 }
@@ -0,0 +1,108 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+	"github.com/google/go-github/v60/github"
+)
+
+func newTestRetryer(maxWait time.Duration) *Retryer {
+	return NewRetryer(RetryConfig{
+		MaxRetries:     1,
+		InitialBackoff: time.Hour, // Fails the test through its deadline if used
+		MaxBackoff:     time.Hour,
+		Multiplier:     1,
+		Jitter:         0.2,
+		MaxWait:        maxWait,
+	}, logging.NewNoop())
+}
+
+func secondaryRateLimitError(retryAfter time.Duration) error {
+	return &github.AbuseRateLimitError{
+		Response: &http.Response{
+			StatusCode: http.StatusForbidden,
+			Request:    httptest.NewRequest(http.MethodGet, "/search/code", nil),
+			Header:     http.Header{"X-Ratelimit-Resource": []string{resourceSearch}},
+		},
+		Message:    "You have exceeded a secondary rate limit",
+		RetryAfter: &retryAfter,
+	}
+}
+
+func TestRetryer_HonorsRetryAfter(t *testing.T) {
+	retryer := newTestRetryer(time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	retryAfter := 30 * time.Millisecond
+	calls := 0
+	start := time.Now()
+	err := retryer.Do(ctx, "Search", false, func() error {
+		calls++
+		if calls == 1 {
+			return secondaryRateLimitError(retryAfter)
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	elapsed := time.Since(start)
+	if elapsed < retryAfter {
+		t.Errorf("waited %v, want at least Retry-After %v", elapsed, retryAfter)
+	}
+	if maxJittered := retryAfter + retryAfter/5; elapsed > maxJittered+time.Second {
+		t.Errorf("waited %v, want about %v", elapsed, maxJittered)
+	}
+}
+
+func TestRetryer_FailsFastPastMaxWait(t *testing.T) {
+	retryer := newTestRetryer(time.Minute)
+
+	calls := 0
+	start := time.Now()
+	err := retryer.Do(context.Background(), "Search", true, func() error {
+		calls++
+		return secondaryRateLimitError(time.Hour)
+	})
+
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+
+	var retryLater *port.RetryLaterError
+	if !errors.As(err, &retryLater) {
+		t.Fatalf("Do() error = %v, want RetryLaterError", err)
+	}
+	if retryLater.Resource != resourceSearch {
+		t.Errorf("Resource = %s, want %s", retryLater.Resource, resourceSearch)
+	}
+	if want := start.Add(time.Hour); retryLater.RetryAt.Before(want) || retryLater.RetryAt.After(want.Add(time.Second)) {
+		t.Errorf("RetryAt = %v, want about %v", retryLater.RetryAt, want)
+	}
+	if !errors.Is(err, port.ErrRateLimited) {
+		t.Error("error does not wrap ErrRateLimited")
+	}
+}
+
+func TestRetryer_Jitter(t *testing.T) {
+	retryer := newTestRetryer(time.Minute)
+
+	for i := 0; i < 100; i++ {
+		d := retryer.jitter(time.Second)
+		if d < time.Second || d > 1200*time.Millisecond {
+			t.Fatalf("jitter(1s) = %v, want within [1s, 1.2s]", d)
+		}
+	}
+}
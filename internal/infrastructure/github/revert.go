@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/google/go-github/v60/github"
+)
+
+// ErrRevertConflict is returned when a file touched by the reverted commit has
+// changed again on the target branch, so the revert cannot be applied cleanly.
+var ErrRevertConflict = errors.New("revert conflicts with later changes")
+
+// CheckRevert accepts every repository: reverts are built through the Git
+// Data API.
+func (c *Client) CheckRevert(ctx context.Context, owner, repo string) error {
+	return nil
+}
+
+// RevertCommit builds a revert of sha on top of branch using the Git Data API.
+//
+// The new tree is the branch tree with every path touched by sha restored to
+// its state in sha's first parent, so a merge commit is reverted like
+// git revert -m 1 does. A path that was modified again after sha is treated
+// as a conflict, since resolving it needs a real three-way merge.
+func (c *Client) RevertCommit(ctx context.Context, owner, repo, branch, sha string) (*entity.Commit, error) {
+	var target *github.RepositoryCommit
+	err := c.do(ctx, "GetCommit", idempotent, func() error {
+		var err error
+		target, _, err = c.gh.Repositories.GetCommit(ctx, owner, repo, sha, nil)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", sha, err)
+	}
+	if len(target.Parents) == 0 {
+		return nil, fmt.Errorf("commit %s has no parent to revert to", sha)
+	}
+	parentSHA := target.Parents[0].GetSHA()
+
+	var head *github.Reference
+	err = c.do(ctx, "GetRef", idempotent, func() error {
+		var err error
+		head, _, err = c.gh.Git.GetRef(ctx, owner, repo, "heads/"+branch)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve branch '%s': %w", branch, err)
+	}
+	headSHA := head.Object.GetSHA()
+
+	parentTree, err := c.commitTree(ctx, owner, repo, parentSHA)
+	if err != nil {
+		return nil, err
+	}
+	targetTree, err := c.commitTree(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	headTreeSHA, headTree, err := c.commitTreeWithSHA(ctx, owner, repo, headSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := changedPaths(parentTree, targetTree)
+
+	var entries []*github.TreeEntry
+	for _, path := range paths {
+		if !sameEntry(headTree[path], targetTree[path]) {
+			return nil, fmt.Errorf("%w: %s was modified on %s after %.7s", ErrRevertConflict, path, branch, sha)
+		}
+
+		if original, ok := parentTree[path]; ok {
+			entries = append(entries, &github.TreeEntry{
+				Path: github.String(path),
+				Mode: original.Mode,
+				Type: original.Type,
+				SHA:  original.SHA,
+			})
+			continue
+		}
+
+		// The path did not exist before sha, so the revert deletes it.
+		if current, ok := headTree[path]; ok {
+			entries = append(entries, &github.TreeEntry{
+				Path: github.String(path),
+				Mode: current.Mode,
+				Type: current.Type,
+			})
+		}
+	}
+
+	var tree *github.Tree
+	err = c.do(ctx, "CreateTree", idempotent, func() error {
+		var err error
+		tree, _, err = c.gh.Git.CreateTree(ctx, owner, repo, headTreeSHA, entries)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tree: %w", err)
+	}
+
+	subject := strings.Split(target.GetCommit().GetMessage(), "\n")[0]
+	message := fmt.Sprintf("Revert \"%s\"\n\nThis reverts commit %s.", subject, sha)
+	if len(target.Parents) > 1 {
+		message = fmt.Sprintf("Revert \"%s\"\n\nThis reverts commit %s, reversing\nchanges made to %s.", subject, sha, parentSHA)
+	}
+
+	var commit *github.Commit
+	err = c.do(ctx, "CreateCommit", idempotent, func() error {
+		var err error
+		commit, _, err = c.gh.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+			Message: github.String(message),
+			Tree:    &github.Tree{SHA: tree.SHA},
+			Parents: []*github.Commit{{SHA: github.String(headSHA)}},
+		}, nil)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	err = c.do(ctx, "UpdateRef", idempotent, func() error {
+		_, _, err := c.gh.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+			Ref:    github.String("refs/heads/" + branch),
+			Object: &github.GitObject{SHA: commit.SHA},
+		}, false)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update branch '%s': %w", branch, err)
+	}
+
+	c.logger.Info("created revert commit",
+		"repo", owner+"/"+repo,
+		"branch", branch,
+		"reverted", sha,
+		"sha", commit.GetSHA(),
+	)
+
+	return &entity.Commit{
+		SHA:        commit.GetSHA(),
+		Message:    message,
+		HTMLURL:    commit.GetHTMLURL(),
+		Repository: owner + "/" + repo,
+		Branch:     branch,
+		Parents:    []string{headSHA},
+	}, nil
+}
+
+// changedPaths returns the paths whose entry differs between two trees,
+// sorted. Diffing the trees rather than using the commit's file list also
+// covers merge commits and commits with more files than the API lists.
+func changedPaths(from, to map[string]*github.TreeEntry) []string {
+	var paths []string
+	for path, entry := range from {
+		if other, ok := to[path]; !ok || !sameEntry(entry, other) {
+			paths = append(paths, path)
+		}
+	}
+	for path := range to {
+		if _, ok := from[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// sameEntry reports whether two tree entries, either of which may be
+// missing, have the same content and mode. A mode change such as making a
+// file executable counts as a change.
+func sameEntry(a, b *github.TreeEntry) bool {
+	return (a == nil) == (b == nil) && a.GetSHA() == b.GetSHA() && a.GetMode() == b.GetMode()
+}
+
+// commitTree returns the recursive tree of a commit indexed by path. It
+// holds files, symlinks and submodules; directories are implied by paths.
+func (c *Client) commitTree(ctx context.Context, owner, repo, sha string) (map[string]*github.TreeEntry, error) {
+	_, entries, err := c.commitTreeWithSHA(ctx, owner, repo, sha)
+	return entries, err
+}
+
+func (c *Client) commitTreeWithSHA(ctx context.Context, owner, repo, sha string) (string, map[string]*github.TreeEntry, error) {
+	var commit *github.Commit
+	err := c.do(ctx, "GetGitCommit", idempotent, func() error {
+		var err error
+		commit, _, err = c.gh.Git.GetCommit(ctx, owner, repo, sha)
+		return err
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get commit %s: %w", sha, err)
+	}
+
+	treeSHA := commit.GetTree().GetSHA()
+
+	var tree *github.Tree
+	err = c.do(ctx, "GetTree", idempotent, func() error {
+		var err error
+		tree, _, err = c.gh.Git.GetTree(ctx, owner, repo, treeSHA, true)
+		return err
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get tree %s: %w", treeSHA, err)
+	}
+	if tree.GetTruncated() {
+		return "", nil, fmt.Errorf("tree %s is too large to revert through the API", treeSHA)
+	}
+
+	entries := make(map[string]*github.TreeEntry, len(tree.Entries))
+	for _, e := range tree.Entries {
+		// Submodules are "commit" entries pointing at the submodule's commit.
+		if e.GetType() == "blob" || e.GetType() == "commit" {
+			entries[e.GetPath()] = e
+		}
+	}
+
+	return treeSHA, entries, nil
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// mergeTrees are the trees of a merge commit "merge1" with parents "main1"
+// and "feat1". The merge changed a.go and added c.go; b.go is the same on
+// both sides.
+var mergeTrees = map[string]string{
+	"main1":  `{"sha":"tree-main1","tree":[{"path":"a.go","mode":"100644","type":"blob","sha":"A1"},{"path":"b.go","mode":"100644","type":"blob","sha":"B1"}]}`,
+	"merge1": `{"sha":"tree-merge1","tree":[{"path":"a.go","mode":"100644","type":"blob","sha":"A2"},{"path":"b.go","mode":"100644","type":"blob","sha":"B1"},{"path":"c.go","mode":"100644","type":"blob","sha":"C1"}]}`,
+}
+
+// newFakeGitData serves the commit, ref and Git Data endpoints a revert
+// uses for the merge commit "merge1" with parents "main1" and "feat1", with
+// the tree of each commit taken from trees. The branch "revert" points at
+// head. It returns the decoded bodies of the created tree and commit.
+func newFakeGitData(t *testing.T, trees map[string]string, head string) (*Client, *map[string]any, *map[string]any) {
+	t.Helper()
+
+	var createdTree, createdCommit map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/api/v3")
+
+		switch {
+		case r.Method == http.MethodGet && path == "/repos/acme/api/commits/merge1":
+			w.Write([]byte(`{"sha":"merge1","commit":{"message":"Merge pull request #5 from acme/feature\n\nAdd c"},
+				"parents":[{"sha":"main1"},{"sha":"feat1"}]}`))
+		case r.Method == http.MethodGet && path == "/repos/acme/api/git/ref/heads/revert":
+			w.Write([]byte(`{"ref":"refs/heads/revert","object":{"sha":"` + head + `","type":"commit"}}`))
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/repos/acme/api/git/commits/"):
+			sha := strings.TrimPrefix(path, "/repos/acme/api/git/commits/")
+			w.Write([]byte(`{"sha":"` + sha + `","tree":{"sha":"tree-` + sha + `"}}`))
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/repos/acme/api/git/trees/tree-"):
+			w.Write([]byte(trees[strings.TrimPrefix(path, "/repos/acme/api/git/trees/tree-")]))
+		case r.Method == http.MethodPost && path == "/repos/acme/api/git/trees":
+			json.NewDecoder(r.Body).Decode(&createdTree)
+			w.Write([]byte(`{"sha":"tree-revert"}`))
+		case r.Method == http.MethodPost && path == "/repos/acme/api/git/commits":
+			json.NewDecoder(r.Body).Decode(&createdCommit)
+			w.Write([]byte(`{"sha":"revert1"}`))
+		case r.Method == http.MethodPatch && path == "/repos/acme/api/git/refs/heads/revert":
+			w.Write([]byte(`{"ref":"refs/heads/revert","object":{"sha":"revert1","type":"commit"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return newTestClient(t, server.URL), &createdTree, &createdCommit
+}
+
+func TestClient_RevertCommitMergeUsesFirstParent(t *testing.T) {
+	client, createdTree, createdCommit := newFakeGitData(t, mergeTrees, "merge1")
+
+	commit, err := client.RevertCommit(context.Background(), "acme", "api", "revert", "merge1")
+	if err != nil {
+		t.Fatalf("RevertCommit: %v", err)
+	}
+	if commit.SHA != "revert1" || len(commit.Parents) != 1 || commit.Parents[0] != "merge1" {
+		t.Errorf("commit = %+v, want revert1 on top of merge1", commit)
+	}
+
+	if (*createdTree)["base_tree"] != "tree-merge1" {
+		t.Errorf("base_tree = %v, want tree-merge1", (*createdTree)["base_tree"])
+	}
+	entries := make(map[string]any)
+	for _, e := range (*createdTree)["tree"].([]any) {
+		entry := e.(map[string]any)
+		entries[entry["path"].(string)] = entry["sha"]
+	}
+	if len(entries) != 2 {
+		t.Fatalf("tree entries = %v, want a.go and c.go", entries)
+	}
+	if entries["a.go"] != "A1" {
+		t.Errorf("a.go sha = %v, want A1 from the first parent", entries["a.go"])
+	}
+	if sha, ok := entries["c.go"]; !ok || sha != nil {
+		t.Errorf("c.go sha = %v, want null to delete it", sha)
+	}
+
+	message, _ := (*createdCommit)["message"].(string)
+	if !strings.HasPrefix(message, `Revert "Merge pull request #5 from acme/feature"`) || !strings.Contains(message, "changes made to main1") {
+		t.Errorf("message = %q, want revert of the merge against main1", message)
+	}
+}
+
+func TestClient_RevertCommitRestoresSubmodule(t *testing.T) {
+	trees := map[string]string{
+		"main1":  `{"sha":"tree-main1","tree":[{"path":"a.go","mode":"100644","type":"blob","sha":"A1"},{"path":"lib","mode":"160000","type":"commit","sha":"L1"}]}`,
+		"merge1": `{"sha":"tree-merge1","tree":[{"path":"a.go","mode":"100644","type":"blob","sha":"A1"},{"path":"lib","mode":"160000","type":"commit","sha":"L2"}]}`,
+	}
+	client, createdTree, _ := newFakeGitData(t, trees, "merge1")
+
+	if _, err := client.RevertCommit(context.Background(), "acme", "api", "revert", "merge1"); err != nil {
+		t.Fatalf("RevertCommit: %v", err)
+	}
+
+	entries, _ := (*createdTree)["tree"].([]any)
+	if len(entries) != 1 {
+		t.Fatalf("tree entries = %v, want only lib", entries)
+	}
+	entry := entries[0].(map[string]any)
+	if entry["path"] != "lib" || entry["sha"] != "L1" || entry["type"] != "commit" || entry["mode"] != "160000" {
+		t.Errorf("entry = %v, want submodule lib back at L1", entry)
+	}
+}
+
+func TestClient_RevertCommitModeChangeConflicts(t *testing.T) {
+	// a.go was made executable on the branch after the merge.
+	trees := map[string]string{
+		"main1":  mergeTrees["main1"],
+		"merge1": mergeTrees["merge1"],
+		"head1":  `{"sha":"tree-head1","tree":[{"path":"a.go","mode":"100755","type":"blob","sha":"A2"},{"path":"b.go","mode":"100644","type":"blob","sha":"B1"},{"path":"c.go","mode":"100644","type":"blob","sha":"C1"}]}`,
+	}
+	client, createdTree, _ := newFakeGitData(t, trees, "head1")
+
+	_, err := client.RevertCommit(context.Background(), "acme", "api", "revert", "merge1")
+	if !errors.Is(err, ErrRevertConflict) || !strings.Contains(err.Error(), "a.go") {
+		t.Errorf("RevertCommit() error = %v, want a conflict on a.go", err)
+	}
+	if *createdTree != nil {
+		t.Errorf("created tree %v, want none after a conflict", *createdTree)
+	}
+}
@@ -0,0 +1,682 @@
+// Package gitlab implements port.GitHubClient on the GitLab REST API (v4).
+// Merge requests map to pull requests, pipelines to workflow runs, and the
+// repository compare endpoint to branch comparisons.
+//
+// Projects are addressed as owner/repo, so only projects directly under a
+// user or top-level group are supported.
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// DefaultURL is the GitLab instance used when none is configured.
+const DefaultURL = "https://gitlab.com"
+
+// maxPerPage is the largest page size the GitLab API accepts.
+const maxPerPage = 100
+
+type Client struct {
+	baseURL string // API root, e.g. https://gitlab.com/api/v4
+	token   string
+	http    *http.Client
+	logger  *logging.Logger
+}
+
+// NewClient creates a GitLab client for the instance at instanceURL, e.g.
+// https://gitlab.example.com, authenticating with a personal, group or
+// project access token.
+func NewClient(instanceURL, token string, logger *logging.Logger) (*Client, error) {
+	if instanceURL == "" {
+		instanceURL = DefaultURL
+	}
+	u, err := url.Parse(instanceURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid GitLab URL %q: expected an absolute http(s) URL", instanceURL)
+	}
+
+	clientLogger := logger.WithComponent("gitlab")
+	clientLogger.Info("gitlab client initialized", "url", u.String())
+
+	return &Client{
+		baseURL: strings.TrimSuffix(u.String(), "/") + "/api/v4",
+		token:   token,
+		http:    &http.Client{},
+		logger:  clientLogger,
+	}, nil
+}
+
+// APIError is an error response from the GitLab API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gitlab: %d %s", e.StatusCode, e.Message)
+}
+
+// translateError wraps API errors with the matching port error.
+func translateError(err *APIError) error {
+	switch err.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %w", port.ErrUnauthorized, err)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %w", port.ErrNotFound, err)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %w", port.ErrRateLimited, err)
+	default:
+		return err
+	}
+}
+
+// project returns the URL path of a project, whose full path is passed
+// URL-encoded in place of its ID.
+func project(owner, repo string) string {
+	return "/projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+// do sends a request to the API and decodes the JSON response into out,
+// when not nil. It returns the response so callers can read pagination
+// headers; its body is already closed.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return resp, translateError(&APIError{StatusCode: resp.StatusCode, Message: errorMessage(resp.Body)})
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, fmt.Errorf("gitlab: decode %s %s: %w", method, path, err)
+		}
+	}
+	return resp, nil
+}
+
+// errorMessage extracts the message of an error response, which GitLab
+// puts in "message" or "error" as a string or an object.
+func errorMessage(body io.Reader) string {
+	var payload struct {
+		Message json.RawMessage `json:"message"`
+		Error   string          `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(body, 64<<10))
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return strings.TrimSpace(string(data))
+	}
+
+	var message string
+	if err := json.Unmarshal(payload.Message, &message); err == nil {
+		return message
+	}
+	if len(payload.Message) > 0 {
+		return string(payload.Message)
+	}
+	return payload.Error
+}
+
+// listPages fetches successive pages of path until the API reports no next
+// page or limit items were collected. A limit of 0 collects every page.
+func listPages[T any](ctx context.Context, c *Client, path string, query url.Values, limit int) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	perPage := maxPerPage
+	if limit > 0 && limit < maxPerPage {
+		perPage = limit
+	}
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	var all []T
+	for page := "1"; page != ""; {
+		query.Set("page", page)
+
+		var items []T
+		resp, err := c.do(ctx, http.MethodGet, path, query, nil, &items)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+		if limit > 0 && len(all) >= limit {
+			return all[:limit], nil
+		}
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return all, nil
+}
+
+func (c *Client) ListRepositories(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+	query := url.Values{
+		"membership": {"true"},
+		"order_by":   {"last_activity_at"},
+	}
+	if !includeArchived {
+		query.Set("archived", "false")
+	}
+
+	projects, err := listPages[apiProject](ctx, c, "/projects", query, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []entity.Repository
+	for _, p := range projects {
+		if filter != "" && !strings.Contains(strings.ToLower(p.PathWithNamespace), strings.ToLower(filter)) {
+			continue
+		}
+		repos = append(repos, toRepository(p))
+	}
+
+	c.logger.Debug("listed projects", "count", len(repos))
+	return repos, nil
+}
+
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*entity.Repository, error) {
+	var p apiProject
+	if _, err := c.do(ctx, http.MethodGet, project(owner, repo), nil, nil, &p); err != nil {
+		return nil, err
+	}
+	result := toRepository(p)
+	return &result, nil
+}
+
+// ListPullRequests lists merge requests. With no repository set, it goes
+// through the listed projects like the GitHub client does.
+func (c *Client) ListPullRequests(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+	limit := 30
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	if filter.Repository != "" {
+		owner, repo, ok := strings.Cut(filter.Repository, "/")
+		if !ok {
+			return nil, nil
+		}
+		return c.listMergeRequests(ctx, owner, repo, filter.State, limit)
+	}
+
+	repos, err := c.ListRepositories(ctx, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []entity.PullRequest
+	for _, r := range repos {
+		owner, repo, ok := strings.Cut(r.FullName, "/")
+		if !ok {
+			continue
+		}
+		prs, err := c.listMergeRequests(ctx, owner, repo, filter.State, limit)
+		if err != nil {
+			continue
+		}
+		all = append(all, prs...)
+		if len(all) >= limit {
+			return all[:limit], nil
+		}
+	}
+	return all, nil
+}
+
+// listMergeRequests lists a project's merge requests in a GitHub state:
+// open, closed (closed or merged) or all.
+func (c *Client) listMergeRequests(ctx context.Context, owner, repo, state string, limit int) ([]entity.PullRequest, error) {
+	query := url.Values{"order_by": {"updated_at"}}
+	switch state {
+	case "", "open":
+		query.Set("state", "opened")
+	case "all":
+		query.Set("state", "all")
+	}
+
+	var mrs []apiMergeRequest
+	if state == "closed" {
+		// GitLab keeps merged apart from closed; list both and interleave
+		// them newest first.
+		for _, s := range []string{"merged", "closed"} {
+			query.Set("state", s)
+			page, err := listPages[apiMergeRequest](ctx, c, project(owner, repo)+"/merge_requests", query, limit)
+			if err != nil {
+				return nil, err
+			}
+			mrs = append(mrs, page...)
+		}
+		sort.SliceStable(mrs, func(i, j int) bool {
+			return mrs[i].UpdatedAt.After(mrs[j].UpdatedAt)
+		})
+		mrs = mrs[:min(len(mrs), limit)]
+	} else {
+		var err error
+		mrs, err = listPages[apiMergeRequest](ctx, c, project(owner, repo)+"/merge_requests", query, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	prs := make([]entity.PullRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		prs = append(prs, toPullRequest(mr, owner+"/"+repo))
+	}
+	return prs, nil
+}
+
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*entity.PullRequest, error) {
+	var mr apiMergeRequest
+	if _, err := c.do(ctx, http.MethodGet, mergeRequest(owner, repo, number), nil, nil, &mr); err != nil {
+		return nil, err
+	}
+	result := toPullRequest(mr, owner+"/"+repo)
+	return &result, nil
+}
+
+func mergeRequest(owner, repo string, number int) string {
+	return project(owner, repo) + "/merge_requests/" + strconv.Itoa(number)
+}
+
+// CreatePullRequest opens a merge request. GitLab marks drafts by title.
+func (c *Client) CreatePullRequest(ctx context.Context, owner, repo, title, body, head, base string, draft bool) (*entity.PullRequest, error) {
+	if draft {
+		title = "Draft: " + title
+	}
+
+	var mr apiMergeRequest
+	_, err := c.do(ctx, http.MethodPost, project(owner, repo)+"/merge_requests", nil, map[string]any{
+		"title":         title,
+		"description":   body,
+		"source_branch": head,
+		"target_branch": base,
+	}, &mr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("created merge request",
+		"repo", owner+"/"+repo,
+		"number", mr.IID,
+		"head", head,
+		"base", base,
+	)
+
+	result := toPullRequest(mr, owner+"/"+repo)
+	return &result, nil
+}
+
+// MergePullRequest merges a merge request. GitLab sets fast-forward and
+// rebase behavior per project, so only the merge and squash methods can be
+// chosen per request.
+func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, number int, method, commitTitle string) (*entity.MergeResult, error) {
+	params := map[string]any{}
+	switch entity.MergeMethod(method) {
+	case "", entity.MergeMethodMerge:
+		if commitTitle != "" {
+			params["merge_commit_message"] = commitTitle
+		}
+	case entity.MergeMethodSquash:
+		params["squash"] = true
+		if commitTitle != "" {
+			params["squash_commit_message"] = commitTitle
+		}
+	default:
+		return nil, &port.UnsupportedError{
+			Provider:  "gitlab",
+			Operation: fmt.Sprintf("choosing the %s merge method per merge request", method),
+		}
+	}
+
+	var mr apiMergeRequest
+	if _, err := c.do(ctx, http.MethodPut, mergeRequest(owner, repo, number)+"/merge", nil, params, &mr); err != nil {
+		return nil, err
+	}
+
+	sha := mr.MergeSHA
+	if mr.SquashSHA != "" {
+		sha = mr.SquashSHA
+	}
+
+	c.logger.Info("merged merge request",
+		"repo", owner+"/"+repo,
+		"number", number,
+		"method", method,
+	)
+
+	return &entity.MergeResult{
+		Success:     mr.State == "merged",
+		SHA:         sha,
+		Message:     "Merge request merged",
+		PRURL:       mr.WebURL,
+		PRNumber:    number,
+		MergeMethod: entity.MergeMethod(method),
+	}, nil
+}
+
+// ListCommits lists commits on a branch, or the default branch. With no
+// repository set, it goes through the listed projects.
+func (c *Client) ListCommits(ctx context.Context, filter entity.CommitFilter) ([]entity.Commit, error) {
+	limit := 30
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	if filter.Repository != "" {
+		owner, repo, ok := strings.Cut(filter.Repository, "/")
+		if !ok {
+			return nil, nil
+		}
+		return c.listCommits(ctx, owner, repo, filter.Branch, filter.Since, limit)
+	}
+
+	repos, err := c.ListRepositories(ctx, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []entity.Commit
+	for _, r := range repos {
+		owner, repo, ok := strings.Cut(r.FullName, "/")
+		if !ok {
+			continue
+		}
+		commits, err := c.listCommits(ctx, owner, repo, filter.Branch, filter.Since, limit)
+		if err != nil {
+			continue
+		}
+		all = append(all, commits...)
+		if len(all) >= limit {
+			return all[:limit], nil
+		}
+	}
+	return all, nil
+}
+
+func (c *Client) listCommits(ctx context.Context, owner, repo, branch string, since *time.Time, limit int) ([]entity.Commit, error) {
+	query := url.Values{}
+	if branch != "" {
+		query.Set("ref_name", branch)
+	}
+	if since != nil {
+		query.Set("since", since.Format(time.RFC3339))
+	}
+
+	items, err := listPages[apiCommit](ctx, c, project(owner, repo)+"/repository/commits", query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]entity.Commit, 0, len(items))
+	for _, item := range items {
+		commits = append(commits, toCommit(item, owner+"/"+repo, branch))
+	}
+	return commits, nil
+}
+
+// GetCommit returns a commit with its stats and changed files.
+func (c *Client) GetCommit(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+	path := project(owner, repo) + "/repository/commits/" + url.PathEscape(sha)
+
+	var item apiCommit
+	if _, err := c.do(ctx, http.MethodGet, path, nil, nil, &item); err != nil {
+		return nil, err
+	}
+	diffs, err := listPages[apiDiff](ctx, c, path+"/diff", nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	commit := toCommit(item, owner+"/"+repo, "")
+	for _, d := range diffs {
+		commit.Files = append(commit.Files, toChangedFile(d))
+	}
+	return &commit, nil
+}
+
+// ListWorkflowRuns lists pipelines, newest first. GitLab runs one pipeline
+// configuration per project, so filter.Workflow does not narrow the list.
+func (c *Client) ListWorkflowRuns(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+	limit := 10
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	owner, repo, ok := strings.Cut(filter.Repository, "/")
+	if !ok {
+		return nil, nil
+	}
+
+	query := url.Values{"order_by": {"id"}, "sort": {"desc"}}
+	if filter.Branch != "" {
+		query.Set("ref", filter.Branch)
+	}
+
+	pipelines, err := listPages[apiPipeline](ctx, c, project(owner, repo)+"/pipelines", query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]entity.WorkflowRun, 0, len(pipelines))
+	for _, p := range pipelines {
+		runs = append(runs, toWorkflowRun(p, filter.Repository))
+	}
+	return runs, nil
+}
+
+func (c *Client) GetWorkflowRun(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+	var p apiPipeline
+	if _, err := c.do(ctx, http.MethodGet, pipeline(owner, repo, runID), nil, nil, &p); err != nil {
+		return nil, err
+	}
+	run := toWorkflowRun(p, owner+"/"+repo)
+	return &run, nil
+}
+
+func pipeline(owner, repo string, id int64) string {
+	return project(owner, repo) + "/pipelines/" + strconv.FormatInt(id, 10)
+}
+
+// GetWorkflow describes the project's CI configuration, the only pipeline
+// definition GitLab has. workflowID is not used.
+func (c *Client) GetWorkflow(ctx context.Context, owner, repo, workflowID string) (*entity.Workflow, error) {
+	var p apiProject
+	if _, err := c.do(ctx, http.MethodGet, project(owner, repo), nil, nil, &p); err != nil {
+		return nil, err
+	}
+
+	path := p.CIConfigPath
+	if path == "" {
+		path = ".gitlab-ci.yml"
+	}
+	return &entity.Workflow{
+		ID:      p.ID,
+		Name:    path,
+		Path:    path,
+		State:   "active",
+		HTMLURL: p.WebURL + "/-/pipelines",
+	}, nil
+}
+
+// RerunWorkflow retries the failed jobs of a pipeline, which keeps its ID.
+func (c *Client) RerunWorkflow(ctx context.Context, owner, repo string, runID int64) error {
+	_, err := c.do(ctx, http.MethodPost, pipeline(owner, repo, runID)+"/retry", nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("retried pipeline", "repo", owner+"/"+repo, "pipeline", runID)
+	return nil
+}
+
+// TriggerWorkflow starts a pipeline on ref, passing inputs as CI variables.
+// workflowID is not used; the project's CI configuration runs.
+func (c *Client) TriggerWorkflow(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error {
+	variables := make([]map[string]string, 0, len(inputs))
+	for key, value := range inputs {
+		variables = append(variables, map[string]string{"key": key, "value": value})
+	}
+
+	_, err := c.do(ctx, http.MethodPost, project(owner, repo)+"/pipeline", nil, map[string]any{
+		"ref":       ref,
+		"variables": variables,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("triggered pipeline", "repo", owner+"/"+repo, "ref", ref)
+	return nil
+}
+
+// CompareBranches compares head to base from their merge base, like
+// GitHub's three-dot compare. GitLab reports no behind count, so a second
+// compare in the other direction provides it.
+func (c *Client) CompareBranches(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+	ahead, err := c.compare(ctx, owner, repo, base, head)
+	if err != nil {
+		return nil, err
+	}
+	behind, err := c.compare(ctx, owner, repo, head, base)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []entity.Commit
+	for _, commit := range ahead.Commits {
+		commits = append(commits, toCommit(commit, owner+"/"+repo, head))
+	}
+
+	var files []entity.ChangedFile
+	for _, d := range ahead.Diffs {
+		files = append(files, toChangedFile(d))
+	}
+
+	aheadBy, behindBy := len(ahead.Commits), len(behind.Commits)
+	status := "identical"
+	switch {
+	case aheadBy > 0 && behindBy > 0:
+		status = "diverged"
+	case aheadBy > 0:
+		status = "ahead"
+	case behindBy > 0:
+		status = "behind"
+	}
+
+	return &entity.BranchComparison{
+		Repository:       owner + "/" + repo,
+		ProdBranch:       base,
+		DevBranch:        head,
+		AheadBy:          aheadBy,
+		BehindBy:         behindBy,
+		TotalCommits:     aheadBy,
+		GitHubStatus:     status,
+		Commits:          commits,
+		Files:            files,
+		FilesTruncated:   ahead.CompareTimeout || behind.CompareTimeout,
+		CommitsTruncated: ahead.CompareTimeout || behind.CompareTimeout,
+	}, nil
+}
+
+func (c *Client) compare(ctx context.Context, owner, repo, from, to string) (*apiCompare, error) {
+	var result apiCompare
+	_, err := c.do(ctx, http.MethodGet, project(owner, repo)+"/repository/compare", url.Values{
+		"from": {from},
+		"to":   {to},
+	}, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) CreateBranch(ctx context.Context, owner, repo, branch, fromBranch string) error {
+	_, err := c.do(ctx, http.MethodPost, project(owner, repo)+"/repository/branches", url.Values{
+		"branch": {branch},
+		"ref":    {fromBranch},
+	}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create branch '%s' from '%s': %w", branch, fromBranch, err)
+	}
+
+	c.logger.Info("created branch", "repo", owner+"/"+repo, "branch", branch, "from", fromBranch)
+	return nil
+}
+
+func (c *Client) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	_, err := c.do(ctx, http.MethodDelete, project(owner, repo)+"/repository/branches/"+url.PathEscape(branch), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("deleted branch", "repo", owner+"/"+repo, "branch", branch)
+	return nil
+}
+
+// CheckRevert accepts every project: GitLab reverts commits itself.
+func (c *Client) CheckRevert(ctx context.Context, owner, repo string) error {
+	return nil
+}
+
+// RevertCommit uses GitLab's revert endpoint, which commits the revert on
+// top of branch.
+func (c *Client) RevertCommit(ctx context.Context, owner, repo, branch, sha string) (*entity.Commit, error) {
+	var item apiCommit
+	path := project(owner, repo) + "/repository/commits/" + url.PathEscape(sha) + "/revert"
+	if _, err := c.do(ctx, http.MethodPost, path, nil, map[string]string{"branch": branch}, &item); err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("reverted commit", "repo", owner+"/"+repo, "branch", branch, "sha", sha)
+
+	commit := toCommit(item, owner+"/"+repo, branch)
+	return &commit, nil
+}
+
+func (c *Client) GetCurrentUser(ctx context.Context) (string, error) {
+	var user apiUser
+	if _, err := c.do(ctx, http.MethodGet, "/user", nil, nil, &user); err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
@@ -0,0 +1,263 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// newFakeGitLab serves routes, keyed by "METHOD escaped-path", under
+// /api/v4 and rejects requests without the test token.
+func newFakeGitLab(t *testing.T, routes map[string]http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "glpat-test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"401 Unauthorized"}`))
+			return
+		}
+		handler, ok := routes[r.Method+" "+r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"404 Not Found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "glpat-test", logging.NewNoop())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestClient_ListRepositoriesPaginates(t *testing.T) {
+	client := newFakeGitLab(t, map[string]http.HandlerFunc{
+		"GET /api/v4/projects": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("membership") != "true" || r.URL.Query().Get("archived") != "false" {
+				t.Errorf("query = %s, want membership=true&archived=false", r.URL.RawQuery)
+			}
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("X-Next-Page", "2")
+				w.Write([]byte(`[{"id":1,"name":"api","path_with_namespace":"acme/api","visibility":"private","default_branch":"main"}]`))
+				return
+			}
+			w.Write([]byte(`[{"id":2,"name":"web","path_with_namespace":"acme/web","visibility":"public","forked_from_project":{"id":9}}]`))
+		},
+	})
+
+	repos, err := client.ListRepositories(context.Background(), "", false)
+	if err != nil {
+		t.Fatalf("ListRepositories: %v", err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("ListRepositories() returned %d repositories, want 2", len(repos))
+	}
+	if repos[0].FullName != "acme/api" || !repos[0].Private || repos[0].DefaultBranch != "main" {
+		t.Errorf("repos[0] = %+v, want private acme/api on main", repos[0])
+	}
+	if repos[1].Private || !repos[1].Fork {
+		t.Errorf("repos[1] = %+v, want public fork", repos[1])
+	}
+}
+
+func TestClient_ListPullRequestsMapsMergeRequests(t *testing.T) {
+	client := newFakeGitLab(t, map[string]http.HandlerFunc{
+		"GET /api/v4/projects/acme%2Fapi/merge_requests": func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("state") {
+			case "opened":
+				w.Write([]byte(`[{"id":10,"iid":3,"title":"Add cache","state":"opened","draft":true,
+					"author":{"username":"dev"},"source_branch":"feature","target_branch":"main",
+					"merge_status":"can_be_merged","changes_count":"1000+"}]`))
+			case "merged":
+				w.Write([]byte(`[{"iid":2,"state":"merged","updated_at":"2024-05-02T00:00:00Z","merged_at":"2024-05-02T00:00:00Z"}]`))
+			case "closed":
+				w.Write([]byte(`[{"iid":1,"state":"closed","updated_at":"2024-05-01T00:00:00Z"}]`))
+			}
+		},
+	})
+	ctx := context.Background()
+
+	open, err := client.ListPullRequests(ctx, entity.PRFilter{Repository: "acme/api"})
+	if err != nil {
+		t.Fatalf("ListPullRequests(open): %v", err)
+	}
+	if len(open) != 1 {
+		t.Fatalf("ListPullRequests(open) = %v, want 1", open)
+	}
+	pr := open[0]
+	if pr.Number != 3 || pr.State != "open" || !pr.Draft || pr.User != "dev" || pr.HeadBranch != "feature" || pr.BaseBranch != "main" {
+		t.Errorf("PR = %+v, want open draft !3 from feature into main", pr)
+	}
+	if pr.Mergeable == nil || !*pr.Mergeable {
+		t.Errorf("Mergeable = %v, want true", pr.Mergeable)
+	}
+	if pr.ChangedFiles != 1000 {
+		t.Errorf("ChangedFiles = %d, want 1000", pr.ChangedFiles)
+	}
+
+	closed, err := client.ListPullRequests(ctx, entity.PRFilter{Repository: "acme/api", State: "closed"})
+	if err != nil {
+		t.Fatalf("ListPullRequests(closed): %v", err)
+	}
+	if len(closed) != 2 || closed[0].Number != 2 || closed[0].MergedAt == nil || closed[1].State != "closed" {
+		t.Errorf("ListPullRequests(closed) = %+v, want merged !2 then closed !1", closed)
+	}
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	var got map[string]any
+	client := newFakeGitLab(t, map[string]http.HandlerFunc{
+		"POST /api/v4/projects/acme%2Fapi/merge_requests": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"iid":4,"state":"opened","web_url":"https://gitlab.example.com/acme/api/-/merge_requests/4"}`))
+		},
+	})
+
+	pr, err := client.CreatePullRequest(context.Background(), "acme", "api", "Sync", "body", "develop", "main", true)
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if pr.Number != 4 || pr.HTMLURL == "" {
+		t.Errorf("PR = %+v, want !4 with URL", pr)
+	}
+	if got["title"] != "Draft: Sync" || got["source_branch"] != "develop" || got["target_branch"] != "main" {
+		t.Errorf("request = %v, want draft title from develop into main", got)
+	}
+}
+
+func TestClient_MergePullRequest(t *testing.T) {
+	var got map[string]any
+	client := newFakeGitLab(t, map[string]http.HandlerFunc{
+		"PUT /api/v4/projects/acme%2Fapi/merge_requests/4/merge": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&got)
+			w.Write([]byte(`{"iid":4,"state":"merged","squash_commit_sha":"abc123","web_url":"https://gitlab.example.com/acme/api/-/merge_requests/4"}`))
+		},
+	})
+	ctx := context.Background()
+
+	result, err := client.MergePullRequest(ctx, "acme", "api", 4, "squash", "Sync develop")
+	if err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+	if !result.Success || result.SHA != "abc123" {
+		t.Errorf("result = %+v, want merged at abc123", result)
+	}
+	if got["squash"] != true || got["squash_commit_message"] != "Sync develop" {
+		t.Errorf("request = %v, want squash with message", got)
+	}
+
+	_, err = client.MergePullRequest(ctx, "acme", "api", 4, "rebase", "")
+	if !errors.Is(err, port.ErrUnsupported) {
+		t.Errorf("MergePullRequest(rebase) error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestClient_ListWorkflowRunsMapsPipelines(t *testing.T) {
+	client := newFakeGitLab(t, map[string]http.HandlerFunc{
+		"GET /api/v4/projects/acme%2Fapi/pipelines": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("ref") != "main" {
+				t.Errorf("ref = %q, want main", r.URL.Query().Get("ref"))
+			}
+			w.Write([]byte(`[
+				{"id":30,"iid":3,"ref":"main","status":"running","source":"push"},
+				{"id":20,"iid":2,"ref":"main","status":"failed","source":"web"},
+				{"id":10,"iid":1,"ref":"main","status":"success","source":"merge_request_event"}
+			]`))
+		},
+	})
+
+	runs, err := client.ListWorkflowRuns(context.Background(), entity.CIFilter{Repository: "acme/api", Branch: "main"})
+	if err != nil {
+		t.Fatalf("ListWorkflowRuns: %v", err)
+	}
+
+	want := []struct {
+		status, conclusion, event string
+	}{
+		{"in_progress", "", "push"},
+		{"completed", "failure", "workflow_dispatch"},
+		{"completed", "success", "pull_request"},
+	}
+	if len(runs) != len(want) {
+		t.Fatalf("ListWorkflowRuns() returned %d runs, want %d", len(runs), len(want))
+	}
+	for i, w := range want {
+		if runs[i].Status != w.status || runs[i].Conclusion != w.conclusion || runs[i].Event != w.event {
+			t.Errorf("runs[%d] = %s/%s/%s, want %s/%s/%s", i,
+				runs[i].Status, runs[i].Conclusion, runs[i].Event, w.status, w.conclusion, w.event)
+		}
+	}
+}
+
+func TestClient_CompareBranches(t *testing.T) {
+	client := newFakeGitLab(t, map[string]http.HandlerFunc{
+		"GET /api/v4/projects/acme%2Fapi/repository/compare": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("from") == "main" {
+				w.Write([]byte(`{
+					"commits":[{"id":"a1","message":"feat"},{"id":"a2","message":"fix"}],
+					"diffs":[
+						{"old_path":"old.go","new_path":"new.go","renamed_file":true,"diff":"@@ -1 +1 @@\n-a\n+b\n"},
+						{"new_path":"added.go","new_file":true,"diff":"@@ -0,0 +1,2 @@\n+x\n+y\n"}
+					]}`))
+				return
+			}
+			w.Write([]byte(`{"commits":[{"id":"b1","message":"hotfix"}],"diffs":[]}`))
+		},
+	})
+
+	comparison, err := client.CompareBranches(context.Background(), "acme", "api", "main", "develop")
+	if err != nil {
+		t.Fatalf("CompareBranches: %v", err)
+	}
+	if comparison.AheadBy != 2 || comparison.BehindBy != 1 || comparison.GitHubStatus != "diverged" {
+		t.Errorf("comparison = ahead %d, behind %d, %s; want ahead 2, behind 1, diverged",
+			comparison.AheadBy, comparison.BehindBy, comparison.GitHubStatus)
+	}
+	if len(comparison.Files) != 2 {
+		t.Fatalf("Files = %v, want 2", comparison.Files)
+	}
+	renamed, added := comparison.Files[0], comparison.Files[1]
+	if renamed.Status != "renamed" || renamed.PreviousFilename != "old.go" || renamed.Additions != 1 || renamed.Deletions != 1 {
+		t.Errorf("renamed = %+v, want old.go renamed with +1/-1", renamed)
+	}
+	if added.Status != "added" || added.Additions != 2 {
+		t.Errorf("added = %+v, want added with +2", added)
+	}
+}
+
+func TestClient_TranslatesErrors(t *testing.T) {
+	client := newFakeGitLab(t, nil)
+	ctx := context.Background()
+
+	_, err := client.GetRepository(ctx, "acme", "missing")
+	if !errors.Is(err, port.ErrNotFound) {
+		t.Errorf("GetRepository() error = %v, want ErrNotFound", err)
+	}
+
+	client.token = "wrong"
+	_, err = client.GetCurrentUser(ctx)
+	if !errors.Is(err, port.ErrUnauthorized) {
+		t.Errorf("GetCurrentUser() error = %v, want ErrUnauthorized", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "401 Unauthorized" {
+		t.Errorf("GetCurrentUser() error = %v, want API message", err)
+	}
+}
+
+func TestClient_ImplementsPort(t *testing.T) {
+	var _ port.GitHubClient = (*Client)(nil)
+}
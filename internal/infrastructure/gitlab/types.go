@@ -0,0 +1,288 @@
+package gitlab
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+// API response types, limited to the fields the client maps.
+
+type apiProject struct {
+	ID                int64     `json:"id"`
+	Name              string    `json:"name"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	Description       string    `json:"description"`
+	Visibility        string    `json:"visibility"`
+	Archived          bool      `json:"archived"`
+	ForkedFromProject *struct{} `json:"forked_from_project"`
+	DefaultBranch     string    `json:"default_branch"`
+	StarCount         int       `json:"star_count"`
+	ForksCount        int       `json:"forks_count"`
+	OpenIssuesCount   int       `json:"open_issues_count"`
+	WebURL            string    `json:"web_url"`
+	HTTPURLToRepo     string    `json:"http_url_to_repo"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	CIConfigPath      string    `json:"ci_config_path"`
+}
+
+type apiUser struct {
+	Username string `json:"username"`
+}
+
+type apiMergeRequest struct {
+	ID           int64      `json:"id"`
+	IID          int        `json:"iid"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	State        string     `json:"state"`
+	Draft        bool       `json:"draft"`
+	WebURL       string     `json:"web_url"`
+	Author       apiUser    `json:"author"`
+	SourceBranch string     `json:"source_branch"`
+	TargetBranch string     `json:"target_branch"`
+	MergeStatus  string     `json:"merge_status"`
+	ChangesCount string     `json:"changes_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	MergedAt     *time.Time `json:"merged_at"`
+	ClosedAt     *time.Time `json:"closed_at"`
+	Labels       []string   `json:"labels"`
+	Reviewers    []apiUser  `json:"reviewers"`
+	MergeSHA     string     `json:"merge_commit_sha"`
+	SquashSHA    string     `json:"squash_commit_sha"`
+}
+
+type apiCommit struct {
+	ID          string    `json:"id"`
+	Message     string    `json:"message"`
+	AuthorName  string    `json:"author_name"`
+	AuthorEmail string    `json:"author_email"`
+	AuthoredAt  time.Time `json:"authored_date"`
+	WebURL      string    `json:"web_url"`
+	ParentIDs   []string  `json:"parent_ids"`
+	Stats       *struct {
+		Additions int `json:"additions"`
+		Deletions int `json:"deletions"`
+	} `json:"stats"`
+}
+
+type apiDiff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+	Diff        string `json:"diff"`
+}
+
+type apiCompare struct {
+	Commits        []apiCommit `json:"commits"`
+	Diffs          []apiDiff   `json:"diffs"`
+	CompareTimeout bool        `json:"compare_timeout"`
+}
+
+type apiPipeline struct {
+	ID        int64      `json:"id"`
+	IID       int        `json:"iid"`
+	Name      string     `json:"name"`
+	Ref       string     `json:"ref"`
+	SHA       string     `json:"sha"`
+	Status    string     `json:"status"`
+	Source    string     `json:"source"`
+	WebURL    string     `json:"web_url"`
+	User      *apiUser   `json:"user"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	StartedAt *time.Time `json:"started_at"`
+}
+
+func toRepository(p apiProject) entity.Repository {
+	return entity.Repository{
+		ID:            p.ID,
+		Name:          p.Name,
+		FullName:      p.PathWithNamespace,
+		Description:   p.Description,
+		Private:       p.Visibility != "public",
+		Archived:      p.Archived,
+		Fork:          p.ForkedFromProject != nil,
+		DefaultBranch: p.DefaultBranch,
+		Stars:         p.StarCount,
+		Forks:         p.ForksCount,
+		OpenIssues:    p.OpenIssuesCount,
+		HTMLURL:       p.WebURL,
+		CloneURL:      p.HTTPURLToRepo,
+		UpdatedAt:     p.LastActivityAt,
+		PushedAt:      p.LastActivityAt,
+	}
+}
+
+// toPullRequest maps a merge request. GitLab's opened state is GitHub's
+// open; merged and closed merge requests are both closed, told apart by
+// MergedAt.
+func toPullRequest(mr apiMergeRequest, repo string) entity.PullRequest {
+	state := "closed"
+	if mr.State == "opened" {
+		state = "open"
+	}
+
+	var mergeable *bool
+	switch mr.MergeStatus {
+	case "can_be_merged":
+		mergeable = new(bool)
+		*mergeable = true
+	case "cannot_be_merged", "cannot_be_merged_recheck":
+		mergeable = new(bool)
+	}
+
+	var reviewers []string
+	for _, r := range mr.Reviewers {
+		reviewers = append(reviewers, r.Username)
+	}
+
+	// changes_count is a string, capped as "1000+"
+	changedFiles, _ := strconv.Atoi(strings.TrimSuffix(mr.ChangesCount, "+"))
+
+	return entity.PullRequest{
+		ID:           mr.ID,
+		Number:       mr.IID,
+		Title:        mr.Title,
+		Body:         mr.Description,
+		State:        state,
+		Draft:        mr.Draft,
+		HTMLURL:      mr.WebURL,
+		User:         mr.Author.Username,
+		HeadBranch:   mr.SourceBranch,
+		BaseBranch:   mr.TargetBranch,
+		Mergeable:    mergeable,
+		ChangedFiles: changedFiles,
+		CreatedAt:    mr.CreatedAt,
+		UpdatedAt:    mr.UpdatedAt,
+		MergedAt:     mr.MergedAt,
+		ClosedAt:     mr.ClosedAt,
+		Labels:       mr.Labels,
+		Reviewers:    reviewers,
+		Repository:   repo,
+	}
+}
+
+func toCommit(c apiCommit, repo, branch string) entity.Commit {
+	commit := entity.Commit{
+		SHA:         c.ID,
+		Message:     c.Message,
+		Author:      c.AuthorName,
+		AuthorEmail: c.AuthorEmail,
+		Date:        c.AuthoredAt,
+		HTMLURL:     c.WebURL,
+		Repository:  repo,
+		Branch:      branch,
+		Parents:     c.ParentIDs,
+	}
+	if c.Stats != nil {
+		commit.Additions = c.Stats.Additions
+		commit.Deletions = c.Stats.Deletions
+	}
+	return commit
+}
+
+// toChangedFile maps a diff, counting added and removed lines from its
+// patch since GitLab does not report them. GitLab's patches start at the
+// first hunk, without file header lines.
+func toChangedFile(d apiDiff) entity.ChangedFile {
+	file := entity.ChangedFile{
+		Filename: d.NewPath,
+		Status:   "modified",
+		Patch:    d.Diff,
+	}
+	switch {
+	case d.NewFile:
+		file.Status = "added"
+	case d.DeletedFile:
+		file.Status = "removed"
+	case d.RenamedFile:
+		file.Status = "renamed"
+		file.PreviousFilename = d.OldPath
+	}
+
+	for _, line := range strings.Split(d.Diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			file.Additions++
+		case strings.HasPrefix(line, "-"):
+			file.Deletions++
+		}
+	}
+	file.Changes = file.Additions + file.Deletions
+
+	return file
+}
+
+// toWorkflowRun maps a pipeline onto GitHub's run vocabulary, which the use
+// cases rely on for status, conclusion and trigger event.
+func toWorkflowRun(p apiPipeline, repo string) entity.WorkflowRun {
+	status, conclusion := runStatus(p.Status)
+
+	name := p.Name
+	if name == "" {
+		name = "pipeline"
+	}
+
+	run := entity.WorkflowRun{
+		ID:           p.ID,
+		Name:         name,
+		WorkflowName: name,
+		HeadBranch:   p.Ref,
+		HeadSHA:      p.SHA,
+		Status:       status,
+		Conclusion:   conclusion,
+		HTMLURL:      p.WebURL,
+		RunNumber:    p.IID,
+		RunAttempt:   1, // A retry reuses the pipeline; only its status and update time change
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+		Repository:   repo,
+		Event:        runEvent(p.Source),
+	}
+	if p.StartedAt != nil {
+		run.RunStartedAt = *p.StartedAt
+	}
+	if p.User != nil {
+		run.Actor = p.User.Username
+	}
+	return run
+}
+
+// runStatus maps a pipeline status to a run status and conclusion.
+func runStatus(status string) (string, string) {
+	switch status {
+	case "success":
+		return "completed", "success"
+	case "failed":
+		return "completed", "failure"
+	case "canceled":
+		return "completed", "cancelled"
+	case "skipped":
+		return "completed", "skipped"
+	case "running":
+		return "in_progress", ""
+	case "manual":
+		return "waiting", ""
+	default: // created, waiting_for_resource, preparing, pending, scheduled
+		return "queued", ""
+	}
+}
+
+// runEvent maps a pipeline source to the matching GitHub event. Pipelines
+// started by hand or through the API count as workflow_dispatch.
+func runEvent(source string) string {
+	switch source {
+	case "merge_request_event":
+		return "pull_request"
+	case "web", "api", "trigger", "pipeline":
+		return "workflow_dispatch"
+	default: // push, schedule
+		return source
+	}
+}
@@ -0,0 +1,432 @@
+// Package localgit implements the read side of port.GitHubClient on local
+// mirrors, running the git CLI. Repositories live under a root directory as
+// <owner>/<repo>.git or <owner>/<repo>, bare, mirrored or cloned with a
+// work tree.
+//
+// Branch comparisons, commit listings and repository listings cost no API
+// quota and work offline. Everything that needs the hosting service, such as
+// pull requests and workflow runs, returns a *port.UnsupportedError unless a
+// Mirrored client sends it to the service instead.
+package localgit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// maxCompareCommits caps the commits of a comparison like GitHub's compare
+// API does. Counts are exact either way.
+const maxCompareCommits = 250
+
+// provider names local mirrors in unsupported operation errors.
+const provider = "local git"
+
+// commitFormat separates the fields of a commit with unit separators and
+// ends it with a record separator, so messages may hold any text.
+const commitFormat = "%H%x1f%P%x1f%an%x1f%ae%x1f%aI%x1f%B%x1e"
+
+type Client struct {
+	root   string
+	git    string // Path of the git binary
+	logger *logging.Logger
+}
+
+// NewClient creates a client for the repositories under root.
+func NewClient(root string, logger *logging.Logger) (*Client, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("local git root: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("local git root %s is not a directory", root)
+	}
+
+	git, err := exec.LookPath("git")
+	if err != nil {
+		return nil, fmt.Errorf("local git provider needs the git CLI: %w", err)
+	}
+
+	clientLogger := logger.WithComponent("localgit")
+	clientLogger.Info("local git client initialized", "root", root)
+
+	return &Client{root: root, git: git, logger: clientLogger}, nil
+}
+
+// unsupported reports an operation local mirrors cannot perform.
+func unsupported(operation string) error {
+	return &port.UnsupportedError{Provider: provider, Operation: operation}
+}
+
+// gitDir returns the git directory of owner/repo, or ErrNotFound. Names
+// that would leave the root are rejected.
+func (c *Client) gitDir(owner, repo string) (string, error) {
+	for _, name := range []string{owner, repo} {
+		if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+			return "", fmt.Errorf("%w: invalid repository %s/%s", port.ErrNotFound, owner, repo)
+		}
+	}
+
+	base := filepath.Join(c.root, owner, repo)
+	for _, dir := range []string{base + ".git", base, filepath.Join(base, ".git")} {
+		if isGitDir(dir) {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no repository %s/%s under %s", port.ErrNotFound, owner, repo, c.root)
+}
+
+// isGitDir reports whether dir looks like a git directory.
+func isGitDir(dir string) bool {
+	head, err := os.Stat(filepath.Join(dir, "HEAD"))
+	if err != nil || head.IsDir() {
+		return false
+	}
+	objects, err := os.Stat(filepath.Join(dir, "objects"))
+	return err == nil && objects.IsDir()
+}
+
+// run runs git against the repository at dir and returns its output.
+func (c *Client) run(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, c.git, append([]string{"--git-dir", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// resolve returns the commit at the tip of branch, or at HEAD when branch
+// is empty. Branches are looked up as refs/heads/<branch> only, so names
+// cannot be mistaken for options or other revisions.
+func (c *Client) resolve(ctx context.Context, dir, branch string) (string, error) {
+	ref := "HEAD"
+	if branch != "" {
+		ref = "refs/heads/" + branch
+	}
+
+	out, err := c.run(ctx, dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("%w: branch %s", port.ErrNotFound, branch)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// ListRepositories scans the root for <owner>/<repo> repositories. Local
+// mirrors are never archived, so includeArchived has no effect.
+func (c *Client) ListRepositories(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+	owners, err := os.ReadDir(c.root)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []entity.Repository
+	for _, owner := range owners {
+		if !owner.IsDir() || strings.HasPrefix(owner.Name(), ".") {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(c.root, owner.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			name := strings.TrimSuffix(entry.Name(), ".git")
+			fullName := owner.Name() + "/" + name
+			if filter != "" && !strings.Contains(strings.ToLower(fullName), strings.ToLower(filter)) {
+				continue
+			}
+
+			dir, err := c.gitDir(owner.Name(), name)
+			if err != nil {
+				continue // Not a repository
+			}
+			repo, err := c.repository(ctx, dir, owner.Name(), name)
+			if err != nil {
+				return nil, err
+			}
+			repos = append(repos, *repo)
+		}
+	}
+
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].UpdatedAt.After(repos[j].UpdatedAt)
+	})
+
+	c.logger.Debug("listed repositories", "count", len(repos))
+	return repos, nil
+}
+
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*entity.Repository, error) {
+	dir, err := c.gitDir(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return c.repository(ctx, dir, owner, repo)
+}
+
+// repository describes the repository at dir. Its default branch is the
+// one HEAD points to, and it was last updated at its newest branch commit.
+func (c *Client) repository(ctx context.Context, dir, owner, name string) (*entity.Repository, error) {
+	repo := &entity.Repository{
+		Name:     name,
+		FullName: owner + "/" + name,
+		CloneURL: dir,
+	}
+
+	if out, err := c.run(ctx, dir, "symbolic-ref", "--quiet", "--short", "HEAD"); err == nil {
+		repo.DefaultBranch = strings.TrimSpace(out)
+	}
+
+	out, err := c.run(ctx, dir, "for-each-ref", "--sort=-committerdate", "--count=1",
+		"--format=%(committerdate:iso-strict)", "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+	if s := strings.TrimSpace(out); s != "" {
+		if updated, err := time.Parse(time.RFC3339, s); err == nil {
+			repo.UpdatedAt = updated
+			repo.PushedAt = updated
+		}
+	}
+	return repo, nil
+}
+
+func (c *Client) ListPullRequests(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+	return nil, unsupported("pull requests")
+}
+
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*entity.PullRequest, error) {
+	return nil, unsupported("pull requests")
+}
+
+func (c *Client) CreatePullRequest(ctx context.Context, owner, repo, title, body, head, base string, draft bool) (*entity.PullRequest, error) {
+	return nil, unsupported("pull requests")
+}
+
+func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, number int, method, commitTitle string) (*entity.MergeResult, error) {
+	return nil, unsupported("pull requests")
+}
+
+// ListCommits lists commits on a branch, or HEAD, newest first. With no
+// repository set, it goes through the listed repositories.
+func (c *Client) ListCommits(ctx context.Context, filter entity.CommitFilter) ([]entity.Commit, error) {
+	limit := 30
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	if filter.Repository != "" {
+		owner, repo, ok := strings.Cut(filter.Repository, "/")
+		if !ok {
+			return nil, nil
+		}
+		return c.listCommits(ctx, owner, repo, filter.Branch, filter.Since, limit)
+	}
+
+	repos, err := c.ListRepositories(ctx, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []entity.Commit
+	for _, r := range repos {
+		owner, repo, _ := strings.Cut(r.FullName, "/")
+		commits, err := c.listCommits(ctx, owner, repo, filter.Branch, filter.Since, limit)
+		if err != nil {
+			continue
+		}
+		all = append(all, commits...)
+		if len(all) >= limit {
+			return all[:limit], nil
+		}
+	}
+	return all, nil
+}
+
+func (c *Client) listCommits(ctx context.Context, owner, repo, branch string, since *time.Time, limit int) ([]entity.Commit, error) {
+	dir, err := c.gitDir(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := c.resolve(ctx, dir, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"log", "--format=" + commitFormat, "-n", strconv.Itoa(limit)}
+	if since != nil {
+		args = append(args, "--since="+since.Format(time.RFC3339))
+	}
+	out, err := c.run(ctx, dir, append(args, tip)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseCommits(out, owner+"/"+repo, branch), nil
+}
+
+func (c *Client) GetCommit(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+	return nil, unsupported("commit details")
+}
+
+func (c *Client) ListWorkflowRuns(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+	return nil, unsupported("workflow runs")
+}
+
+func (c *Client) GetWorkflowRun(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+	return nil, unsupported("workflow runs")
+}
+
+func (c *Client) GetWorkflow(ctx context.Context, owner, repo, workflowID string) (*entity.Workflow, error) {
+	return nil, unsupported("workflows")
+}
+
+func (c *Client) RerunWorkflow(ctx context.Context, owner, repo string, runID int64) error {
+	return unsupported("workflow runs")
+}
+
+func (c *Client) TriggerWorkflow(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error {
+	return unsupported("workflows")
+}
+
+// CompareBranches compares head to base from their merge base, like
+// GitHub's three-dot compare.
+func (c *Client) CompareBranches(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+	dir, err := c.gitDir(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	baseSHA, err := c.resolve(ctx, dir, base)
+	if err != nil {
+		return nil, err
+	}
+	headSHA, err := c.resolve(ctx, dir, head)
+	if err != nil {
+		return nil, err
+	}
+	rangeSpec := baseSHA + "..." + headSHA
+
+	// Left counts commits only on base, right those only on head.
+	out, err := c.run(ctx, dir, "rev-list", "--left-right", "--count", rangeSpec)
+	if err != nil {
+		return nil, err
+	}
+	left, right, _ := strings.Cut(strings.TrimSpace(out), "\t")
+	behindBy, _ := strconv.Atoi(left)
+	aheadBy, _ := strconv.Atoi(right)
+
+	out, err = c.run(ctx, dir, "log", "--format="+commitFormat, "-n", strconv.Itoa(maxCompareCommits),
+		baseSHA+".."+headSHA)
+	if err != nil {
+		return nil, err
+	}
+	commits := parseCommits(out, owner+"/"+repo, head)
+	// Oldest first, as GitHub lists them
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+
+	files, err := c.changedFiles(ctx, dir, rangeSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	status := "identical"
+	switch {
+	case aheadBy > 0 && behindBy > 0:
+		status = "diverged"
+	case aheadBy > 0:
+		status = "ahead"
+	case behindBy > 0:
+		status = "behind"
+	}
+
+	return &entity.BranchComparison{
+		Repository:       owner + "/" + repo,
+		ProdBranch:       base,
+		DevBranch:        head,
+		AheadBy:          aheadBy,
+		BehindBy:         behindBy,
+		TotalCommits:     aheadBy,
+		GitHubStatus:     status,
+		Commits:          commits,
+		Files:            files,
+		CommitsTruncated: aheadBy > len(commits),
+	}, nil
+}
+
+// changedFiles lists the files changed over rangeSpec with their status
+// and line counts, detecting renames.
+func (c *Client) changedFiles(ctx context.Context, dir, rangeSpec string) ([]entity.ChangedFile, error) {
+	nameStatus, err := c.run(ctx, dir, "diff", "-z", "-M", "--name-status", rangeSpec)
+	if err != nil {
+		return nil, err
+	}
+	numStat, err := c.run(ctx, dir, "diff", "-z", "-M", "--numstat", rangeSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	files := parseNameStatus(nameStatus)
+	counts := parseNumStat(numStat)
+	for i := range files {
+		if n, ok := counts[files[i].Filename]; ok {
+			files[i].Additions = n[0]
+			files[i].Deletions = n[1]
+			files[i].Changes = n[0] + n[1]
+		}
+	}
+	return files, nil
+}
+
+func (c *Client) CreateBranch(ctx context.Context, owner, repo, branch, fromBranch string) error {
+	return unsupported("creating branches on a mirror")
+}
+
+func (c *Client) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	return unsupported("deleting branches on a mirror")
+}
+
+// CheckRevert reports that mirrors are read-only.
+func (c *Client) CheckRevert(ctx context.Context, owner, repo string) error {
+	return unsupported("reverting commits on a mirror")
+}
+
+func (c *Client) RevertCommit(ctx context.Context, owner, repo, branch, sha string) (*entity.Commit, error) {
+	return nil, c.CheckRevert(ctx, owner, repo)
+}
+
+// GetCurrentUser returns the operating system user reading the mirrors.
+func (c *Client) GetCurrentUser(ctx context.Context) (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
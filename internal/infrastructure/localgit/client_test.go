@@ -0,0 +1,220 @@
+package localgit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// git runs git in dir with a fixed identity and fails the test on error.
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Dev", "GIT_AUTHOR_EMAIL=dev@example.com",
+		"GIT_COMMITTER_NAME=Dev", "GIT_COMMITTER_EMAIL=dev@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func commitFile(t *testing.T, dir, name, content, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(t, dir, "add", "-A")
+	git(t, dir, "commit", "-q", "-m", message)
+}
+
+// newMirrorRoot builds a root holding acme/api.git, a bare mirror whose
+// develop branch is two commits ahead of main and one behind, and
+// acme/notes, a regular clone.
+func newMirrorRoot(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	work := t.TempDir()
+	git(t, work, "init", "-q", "-b", "main")
+	commitFile(t, work, "api.go", "package api\n", "Initial commit")
+	commitFile(t, work, "old.go", "one\ntwo\n", "Add old")
+
+	git(t, work, "checkout", "-q", "-b", "develop")
+	commitFile(t, work, "api.go", "package api\n\nfunc New() {}\n", "Add constructor")
+	git(t, work, "mv", "old.go", "new.go")
+	git(t, work, "commit", "-q", "-m", "Rename old")
+
+	git(t, work, "checkout", "-q", "main")
+	commitFile(t, work, "hotfix.go", "fix\n", "Hotfix")
+
+	root := t.TempDir()
+	git(t, root, "clone", "-q", "--mirror", work, filepath.Join(root, "acme", "api.git"))
+	git(t, root, "clone", "-q", work, filepath.Join(root, "acme", "notes"))
+	if err := os.MkdirAll(filepath.Join(root, "acme", "not-a-repo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	client, err := NewClient(newMirrorRoot(t), logging.NewNoop())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestClient_ListRepositoriesScansRoot(t *testing.T) {
+	client := newTestClient(t)
+
+	repos, err := client.ListRepositories(context.Background(), "", false)
+	if err != nil {
+		t.Fatalf("ListRepositories: %v", err)
+	}
+
+	got := make(map[string]entity.Repository)
+	for _, r := range repos {
+		got[r.FullName] = r
+	}
+	if len(got) != 2 {
+		t.Fatalf("ListRepositories() = %v, want acme/api and acme/notes", repos)
+	}
+	api, ok := got["acme/api"]
+	if !ok || api.DefaultBranch != "main" || api.UpdatedAt.IsZero() {
+		t.Errorf("acme/api = %+v, want default branch main with update time", api)
+	}
+
+	filtered, err := client.ListRepositories(context.Background(), "NOTES", false)
+	if err != nil {
+		t.Fatalf("ListRepositories(NOTES): %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].FullName != "acme/notes" {
+		t.Errorf("ListRepositories(NOTES) = %v, want acme/notes", filtered)
+	}
+}
+
+func TestClient_CompareBranches(t *testing.T) {
+	client := newTestClient(t)
+
+	comparison, err := client.CompareBranches(context.Background(), "acme", "api", "main", "develop")
+	if err != nil {
+		t.Fatalf("CompareBranches: %v", err)
+	}
+	if comparison.AheadBy != 2 || comparison.BehindBy != 1 || comparison.GitHubStatus != "diverged" {
+		t.Errorf("comparison = ahead %d, behind %d, %s; want ahead 2, behind 1, diverged",
+			comparison.AheadBy, comparison.BehindBy, comparison.GitHubStatus)
+	}
+	if len(comparison.Commits) != 2 || comparison.Commits[0].Message != "Add constructor" {
+		t.Errorf("Commits = %v, want oldest first", comparison.Commits)
+	}
+
+	files := make(map[string]entity.ChangedFile)
+	for _, f := range comparison.Files {
+		files[f.Filename] = f
+	}
+	if len(files) != 2 {
+		t.Fatalf("Files = %+v, want api.go and new.go", comparison.Files)
+	}
+	if f := files["api.go"]; f.Status != "modified" || f.Additions != 2 || f.Deletions != 0 {
+		t.Errorf("api.go = %+v, want modified with +2", f)
+	}
+	if f := files["new.go"]; f.Status != "renamed" || f.PreviousFilename != "old.go" {
+		t.Errorf("new.go = %+v, want renamed from old.go", f)
+	}
+}
+
+func TestClient_CompareBranchesMissingBranch(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.CompareBranches(context.Background(), "acme", "api", "main", "staging")
+	if !errors.Is(err, port.ErrNotFound) {
+		t.Errorf("CompareBranches(staging) error = %v, want ErrNotFound", err)
+	}
+
+	_, err = client.CompareBranches(context.Background(), "..", "api", "main", "develop")
+	if !errors.Is(err, port.ErrNotFound) {
+		t.Errorf("CompareBranches(../api) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_ListCommits(t *testing.T) {
+	client := newTestClient(t)
+
+	commits, err := client.ListCommits(context.Background(), entity.CommitFilter{
+		Repository: "acme/api",
+		Branch:     "develop",
+		Limit:      3,
+	})
+	if err != nil {
+		t.Fatalf("ListCommits: %v", err)
+	}
+
+	want := []string{"Rename old", "Add constructor", "Add old"}
+	if len(commits) != len(want) {
+		t.Fatalf("ListCommits() returned %d commits, want %d", len(commits), len(want))
+	}
+	for i, message := range want {
+		if commits[i].Message != message {
+			t.Errorf("commits[%d].Message = %q, want %q", i, commits[i].Message, message)
+		}
+	}
+	first := commits[0]
+	if len(first.SHA) != 40 || len(first.Parents) != 1 || first.Author != "Dev" || first.Branch != "develop" || first.Date.IsZero() {
+		t.Errorf("commits[0] = %+v, want full commit details", first)
+	}
+}
+
+func TestClient_UnsupportedOperations(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.ListPullRequests(context.Background(), entity.PRFilter{Repository: "acme/api"})
+	if !errors.Is(err, port.ErrUnsupported) {
+		t.Errorf("ListPullRequests() error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestClient_ImplementsPort(t *testing.T) {
+	var _ port.GitHubClient = (*Client)(nil)
+}
+
+func TestMirrored_SendsOnlyReadsToMirror(t *testing.T) {
+	remote := port.NewMockGitHubClient()
+	remote.ListPullRequestsFunc = func(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+		return []entity.PullRequest{{Number: 7, Repository: filter.Repository}}, nil
+	}
+	remote.CompareBranchesFunc = func(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+		t.Error("CompareBranches reached the remote, want it served by the mirror")
+		return nil, port.ErrUnauthorized
+	}
+	client := NewMirrored(newTestClient(t), remote)
+
+	comparison, err := client.CompareBranches(context.Background(), "acme", "api", "main", "develop")
+	if err != nil || comparison.AheadBy != 2 {
+		t.Errorf("CompareBranches() = %+v, %v; want the mirror's comparison", comparison, err)
+	}
+
+	prs, err := client.ListPullRequests(context.Background(), entity.PRFilter{Repository: "acme/api"})
+	if err != nil || len(prs) != 1 || prs[0].Number != 7 {
+		t.Errorf("ListPullRequests() = %v, %v; want the remote's pull requests", prs, err)
+	}
+	if err := client.CheckRevert(context.Background(), "acme", "api"); err != nil {
+		t.Errorf("CheckRevert() = %v, want the remote to allow reverts", err)
+	}
+}
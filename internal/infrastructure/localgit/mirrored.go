@@ -0,0 +1,41 @@
+package localgit
+
+import (
+	"context"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+// Mirrored serves the reads a mirror can answer from it and sends every
+// other call to the hosting service. Branch comparisons, commit listings and
+// the repository listing come from the mirror, so drift checks stay offline
+// and free of API quota, while pull requests, CI, commit details and
+// rollbacks keep working through remote.
+type Mirrored struct {
+	port.GitHubClient // The hosting service's client
+	mirror            *Client
+}
+
+// NewMirrored combines mirror with remote, the client of the service the
+// mirrored repositories live on.
+func NewMirrored(mirror *Client, remote port.GitHubClient) *Mirrored {
+	return &Mirrored{GitHubClient: remote, mirror: mirror}
+}
+
+func (m *Mirrored) ListRepositories(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+	return m.mirror.ListRepositories(ctx, filter, includeArchived)
+}
+
+func (m *Mirrored) ListCommits(ctx context.Context, filter entity.CommitFilter) ([]entity.Commit, error) {
+	return m.mirror.ListCommits(ctx, filter)
+}
+
+func (m *Mirrored) CompareBranches(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+	return m.mirror.CompareBranches(ctx, owner, repo, base, head)
+}
+
+// GetCurrentUser returns the operating system user reading the mirrors.
+func (m *Mirrored) GetCurrentUser(ctx context.Context) (string, error) {
+	return m.mirror.GetCurrentUser(ctx)
+}
@@ -0,0 +1,96 @@
+package localgit
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+)
+
+// parseCommits parses git log output in commitFormat.
+func parseCommits(out, repo, branch string) []entity.Commit {
+	var commits []entity.Commit
+	for _, record := range strings.Split(out, "\x1e") {
+		record = strings.TrimPrefix(record, "\n")
+		fields := strings.SplitN(record, "\x1f", 6)
+		if len(fields) != 6 {
+			continue
+		}
+
+		date, _ := time.Parse(time.RFC3339, fields[4])
+		commits = append(commits, entity.Commit{
+			SHA:         fields[0],
+			Parents:     strings.Fields(fields[1]),
+			Author:      fields[2],
+			AuthorEmail: fields[3],
+			Date:        date,
+			Message:     strings.TrimRight(fields[5], "\n"),
+			Repository:  repo,
+			Branch:      branch,
+		})
+	}
+	return commits
+}
+
+// fileStatuses maps git's name-status letters to GitHub's file statuses.
+var fileStatuses = map[byte]string{
+	'A': "added",
+	'C': "copied",
+	'D': "removed",
+	'M': "modified",
+	'R': "renamed",
+	'T': "changed",
+}
+
+// parseNameStatus parses git diff -z --name-status output. Renames and
+// copies carry a similarity score and are followed by both paths.
+func parseNameStatus(out string) []entity.ChangedFile {
+	fields := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+
+	var files []entity.ChangedFile
+	for i := 0; i+1 < len(fields); i += 2 {
+		code := fields[i]
+		if code == "" {
+			break
+		}
+		status, ok := fileStatuses[code[0]]
+		if !ok {
+			status = "modified"
+		}
+
+		file := entity.ChangedFile{Filename: fields[i+1], Status: status}
+		if (code[0] == 'R' || code[0] == 'C') && i+2 < len(fields) {
+			file.PreviousFilename = fields[i+1]
+			file.Filename = fields[i+2]
+			i++
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// parseNumStat parses git diff -z --numstat output into added and deleted
+// line counts by file name. Binary files count no lines. A rename leaves
+// the path empty and is followed by the old and new paths.
+func parseNumStat(out string) map[string][2]int {
+	fields := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+
+	counts := make(map[string][2]int)
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		path := parts[2]
+		if path == "" && i+2 < len(fields) {
+			path = fields[i+2]
+			i += 2
+		}
+
+		added, _ := strconv.Atoi(parts[0])
+		deleted, _ := strconv.Atoi(parts[1])
+		counts[path] = [2]int{added, deleted}
+	}
+	return counts
+}
@@ -2,10 +2,15 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
 	"github.com/carlos/mcp-repo-monitor/internal/application/usecase"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/service"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 type Handler struct {
@@ -19,6 +24,8 @@ type Handler struct {
 	createPR        *usecase.CreatePRUseCase
 	mergePR         *usecase.MergePRUseCase
 	deleteBranch    *usecase.DeleteBranchUseCase
+	serverStatus    *usecase.ServerStatusUseCase
+	clearCache      *usecase.ClearCacheUseCase
 	presenter       *Presenter
 }
 
@@ -33,6 +40,8 @@ func NewHandler(
 	createPR *usecase.CreatePRUseCase,
 	mergePR *usecase.MergePRUseCase,
 	deleteBranch *usecase.DeleteBranchUseCase,
+	serverStatus *usecase.ServerStatusUseCase,
+	clearCache *usecase.ClearCacheUseCase,
 	presenter *Presenter,
 ) *Handler {
 	return &Handler{
@@ -46,6 +55,8 @@ func NewHandler(
 		createPR:        createPR,
 		mergePR:         mergePR,
 		deleteBranch:    deleteBranch,
+		serverStatus:    serverStatus,
+		clearCache:      clearCache,
 		presenter:       presenter,
 	}
 }
@@ -53,22 +64,35 @@ func NewHandler(
 func (h *Handler) HandleListStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := getArgs(req)
 
+	ctx, err := withFreshness(ctx, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	input := usecase.ListStatusInput{
-		Filter:   getString(args, "filter"),
-		Archived: getBool(args, "archived"),
+		Filter:    getString(args, "filter"),
+		Archived:  getBool(args, "archived"),
+		SortBy:    getString(args, "sort"),
+		MinHealth: getInt(args, "min_health"),
+		MaxHealth: getOptionalInt(args, "max_health"),
 	}
 
 	statuses, err := h.listStatus.Execute(ctx, input)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list repositories: %v", err)), nil
+	if err != nil && !port.IsPartial(err) {
+		return mcp.NewToolResultError(failureMessage("Failed to list repositories", err)), nil
 	}
 
-	return mcp.NewToolResultText(h.presenter.FormatRepositoryStatuses(statuses)), nil
+	return mcp.NewToolResultText(withPartialNote(h.presenter.FormatRepositoryStatuses(statuses), err)), nil
 }
 
 func (h *Handler) HandleListPRs(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := getArgs(req)
 
+	ctx, err := withFreshness(ctx, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	input := usecase.ListPRsInput{
 		Repository: getString(args, "repo"),
 		State:      getString(args, "state"),
@@ -76,16 +100,21 @@ func (h *Handler) HandleListPRs(ctx context.Context, req mcp.CallToolRequest) (*
 	}
 
 	prs, err := h.listPRs.Execute(ctx, input)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list PRs: %v", err)), nil
+	if err != nil && !port.IsPartial(err) {
+		return mcp.NewToolResultError(failureMessage("Failed to list PRs", err)), nil
 	}
 
-	return mcp.NewToolResultText(h.presenter.FormatPullRequests(prs)), nil
+	return mcp.NewToolResultText(withPartialNote(h.presenter.FormatPullRequests(prs), err)), nil
 }
 
 func (h *Handler) HandleCheckCI(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := getArgs(req)
 
+	ctx, err := withFreshness(ctx, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	repo := getString(args, "repo")
 	if repo == "" {
 		return mcp.NewToolResultError("repo parameter is required"), nil
@@ -100,7 +129,7 @@ func (h *Handler) HandleCheckCI(ctx context.Context, req mcp.CallToolRequest) (*
 
 	runs, err := h.checkCI.Execute(ctx, input)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to check CI: %v", err)), nil
+		return mcp.NewToolResultError(failureMessage("Failed to check CI", err)), nil
 	}
 
 	return mcp.NewToolResultText(h.presenter.FormatWorkflowRuns(runs)), nil
@@ -116,21 +145,24 @@ func (h *Handler) HandleTriggerRollback(ctx context.Context, req mcp.CallToolReq
 
 	strategy := getString(args, "strategy")
 	if strategy == "" {
-		return mcp.NewToolResultError("strategy parameter is required (rerun, revert, or workflow)"), nil
+		return mcp.NewToolResultError("strategy parameter is required (rerun, revert, workflow, or auto)"), nil
 	}
 
 	input := usecase.TriggerRollbackInput{
-		Repository: repo,
-		Strategy:   strategy,
-		WorkflowID: int64(getInt(args, "workflow_id")),
-		RunID:      int64(getInt(args, "run_id")),
-		CommitSHA:  getString(args, "commit_sha"),
-		DryRun:     getBool(args, "dry_run"),
+		Repository:  repo,
+		Strategy:    strategy,
+		WorkflowID:  int64(getInt(args, "workflow_id")),
+		RunID:       int64(getInt(args, "run_id")),
+		CommitSHA:   getString(args, "commit_sha"),
+		DryRun:      getBool(args, "dry_run"),
+		Wait:        getBool(args, "wait"),
+		WaitTimeout: time.Duration(getInt(args, "timeout_seconds")) * time.Second,
+		OnProgress:  progressNotifier(ctx, req),
 	}
 
 	result, err := h.triggerRollback.Execute(ctx, input)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Rollback failed: %v", err)), nil
+		return mcp.NewToolResultError(rollbackErrorMessage(err)), nil
 	}
 
 	return mcp.NewToolResultText(h.presenter.FormatRollbackResult(result)), nil
@@ -139,6 +171,11 @@ func (h *Handler) HandleTriggerRollback(ctx context.Context, req mcp.CallToolReq
 func (h *Handler) HandleRecentCommits(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := getArgs(req)
 
+	ctx, err := withFreshness(ctx, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	input := usecase.RecentCommitsInput{
 		Repository: getString(args, "repo"),
 		Branch:     getString(args, "branch"),
@@ -147,26 +184,31 @@ func (h *Handler) HandleRecentCommits(ctx context.Context, req mcp.CallToolReque
 	}
 
 	commits, err := h.recentCommits.Execute(ctx, input)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list commits: %v", err)), nil
+	if err != nil && !port.IsPartial(err) {
+		return mcp.NewToolResultError(failureMessage("Failed to list commits", err)), nil
 	}
 
-	return mcp.NewToolResultText(h.presenter.FormatCommits(commits)), nil
+	return mcp.NewToolResultText(withPartialNote(h.presenter.FormatCommits(commits), err)), nil
 }
 
 func (h *Handler) HandleCheckDrift(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := getArgs(req)
 
+	ctx, err := withFreshness(ctx, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	input := usecase.CheckDriftInput{
 		Repository: getString(args, "repo"),
 	}
 
 	results, err := h.checkDrift.Execute(ctx, input)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to check drift: %v", err)), nil
+	if err != nil && !port.IsPartial(err) {
+		return mcp.NewToolResultError(failureMessage("Failed to check drift", err)), nil
 	}
 
-	return mcp.NewToolResultText(h.presenter.FormatDriftResults(results)), nil
+	return mcp.NewToolResultText(withPartialNote(h.presenter.FormatDriftResults(results), err)), nil
 }
 
 func (h *Handler) HandleCreateSyncPR(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -186,7 +228,7 @@ func (h *Handler) HandleCreateSyncPR(ctx context.Context, req mcp.CallToolReques
 
 	result, err := h.createSyncPR.Execute(ctx, input)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create sync PR: %v", err)), nil
+		return mcp.NewToolResultError(failureMessage("Failed to create sync PR", err)), nil
 	}
 
 	return mcp.NewToolResultText(h.presenter.FormatSyncPRResult(result)), nil
@@ -227,7 +269,7 @@ func (h *Handler) HandleCreatePR(ctx context.Context, req mcp.CallToolRequest) (
 
 	result, err := h.createPR.Execute(ctx, input)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create PR: %v", err)), nil
+		return mcp.NewToolResultError(failureMessage("Failed to create PR", err)), nil
 	}
 
 	return mcp.NewToolResultText(h.presenter.FormatCreatePRResult(result)), nil
@@ -257,7 +299,7 @@ func (h *Handler) HandleMergePR(ctx context.Context, req mcp.CallToolRequest) (*
 
 	result, err := h.mergePR.Execute(ctx, input)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to merge PR: %v", err)), nil
+		return mcp.NewToolResultError(failureMessage("Failed to merge PR", err)), nil
 	}
 
 	return mcp.NewToolResultText(h.presenter.FormatMergeResult(result)), nil
@@ -284,12 +326,122 @@ func (h *Handler) HandleDeleteBranch(ctx context.Context, req mcp.CallToolReques
 
 	result, err := h.deleteBranch.Execute(ctx, input)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete branch: %v", err)), nil
+		return mcp.NewToolResultError(failureMessage("Failed to delete branch", err)), nil
 	}
 
 	return mcp.NewToolResultText(h.presenter.FormatDeleteBranchResult(result)), nil
 }
 
+func (h *Handler) HandleServerStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	status, err := h.serverStatus.Execute(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(failureMessage("Failed to get server status", err)), nil
+	}
+
+	return mcp.NewToolResultText(h.presenter.FormatServerStatus(status)), nil
+}
+
+func (h *Handler) HandleCacheClear(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := getArgs(req)
+
+	input := usecase.ClearCacheInput{
+		Repository: getString(args, "repo"),
+		Namespace:  getString(args, "namespace"),
+	}
+
+	result, err := h.clearCache.Execute(ctx, input)
+	if err != nil {
+		return mcp.NewToolResultError(failureMessage("Failed to clear cache", err)), nil
+	}
+
+	return mcp.NewToolResultText(h.presenter.FormatClearCacheResult(result)), nil
+}
+
+// rollbackErrorMessage turns rollback validation errors into actionable tool
+// errors; anything else is reported as a generic failure.
+func rollbackErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, service.ErrUnknownStrategy):
+		return fmt.Sprintf("Invalid rollback request: %v", err)
+	case errors.Is(err, service.ErrMissingWorkflowID):
+		return "Invalid rollback request: the 'workflow' strategy needs the workflow_id parameter or a rollback workflow for this repository in repos.json"
+	case errors.Is(err, service.ErrMissingCommitSHA):
+		return "Invalid rollback request: the 'revert' strategy needs the commit_sha parameter"
+	case errors.Is(err, service.ErrRunInProgress):
+		return fmt.Sprintf("Rollback refused: %v. Wait for it to finish or cancel it before re-running", err)
+	case errors.Is(err, service.ErrRunSucceeded):
+		return fmt.Sprintf("Rollback refused: %v, re-running it would not change anything. Use 'revert' or 'workflow' instead", err)
+	case errors.Is(err, service.ErrRollbackInProgress):
+		return fmt.Sprintf("Rollback blocked: %v. Retry once it has finished", err)
+	case errors.Is(err, service.ErrNoStrategy):
+		return fmt.Sprintf("Automatic strategy selection failed: %v. Pick a strategy explicitly", err)
+	case errors.Is(err, port.ErrUnsupported):
+		return fmt.Sprintf("Rollback not possible: %v. Pick another strategy", err)
+	default:
+		return fmt.Sprintf("Rollback failed: %v", err)
+	}
+}
+
+// failureMessage reports err as a failed action, except for operations the
+// repository's provider does not support, which retrying cannot fix.
+func failureMessage(action string, err error) string {
+	if errors.Is(err, port.ErrUnsupported) {
+		return fmt.Sprintf("Not supported for this repository: %v", err)
+	}
+	return fmt.Sprintf("%s: %v", action, err)
+}
+
+// withPartialNote appends the accounts a listing could not reach to text
+// when err is a *port.PartialError.
+func withPartialNote(text string, err error) string {
+	if !port.IsPartial(err) {
+		return text
+	}
+	return fmt.Sprintf("%s\n\n⚠ Incomplete: %v", text, err)
+}
+
+// progressNotifier returns a callback that forwards progress to the client as
+// notifications/progress, or nil when the request carries no progress token.
+func progressNotifier(ctx context.Context, req mcp.CallToolRequest) usecase.ProgressFunc {
+	if req.Params.Meta == nil || req.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return nil
+	}
+
+	token := req.Params.Meta.ProgressToken
+	return func(progress, total float64, message string) {
+		_ = srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": token,
+			"progress":      progress,
+			"total":         total,
+			"message":       message,
+		})
+	}
+}
+
+// withFreshness applies the fresh and max_age parameters of read tools to
+// ctx, for the client to decide whether cached data is recent enough.
+func withFreshness(ctx context.Context, args map[string]any) (context.Context, error) {
+	if getBool(args, "fresh") {
+		return port.WithMaxAge(ctx, 0), nil
+	}
+
+	maxAge := getString(args, "max_age")
+	if maxAge == "" {
+		return ctx, nil
+	}
+
+	d, err := time.ParseDuration(maxAge)
+	if err != nil || d < 0 {
+		return nil, fmt.Errorf("invalid max_age '%s', expected a duration like 30s or 5m", maxAge)
+	}
+	return port.WithMaxAge(ctx, d), nil
+}
+
 func getArgs(req mcp.CallToolRequest) map[string]any {
 	if args, ok := req.Params.Arguments.(map[string]any); ok {
 		return args
@@ -311,6 +463,16 @@ func getInt(args map[string]any, key string) int {
 	return 0
 }
 
+// getOptionalInt returns nil when key is absent, so that 0 can be told apart
+// from no value.
+func getOptionalInt(args map[string]any, key string) *int {
+	if v, ok := args[key].(float64); ok {
+		n := int(v)
+		return &n
+	}
+	return nil
+}
+
 func getBool(args map[string]any, key string) bool {
 	if v, ok := args[key].(bool); ok {
 		return v
@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,22 +32,48 @@ func (p *Presenter) FormatRepositoryStatuses(statuses []entity.RepositoryStatus)
 			ciStatus = "✗"
 		}
 
-		sb.WriteString(fmt.Sprintf("│ %-40s                        │\n", truncate(s.Repository.FullName, 40)))
+		sb.WriteString(fmt.Sprintf("│ %-40s        Health: %3d/100 │\n", truncate(s.Repository.FullName, 40), s.HealthScore))
 		sb.WriteString(fmt.Sprintf("│   PRs: %-3d │ CI: %s │ Updated: %-20s      │\n",
 			s.OpenPRs,
 			ciStatus,
 			formatTime(s.LastCommitAt),
 		))
 
+		if len(s.BranchCI) > 0 {
+			var branches []string
+			for _, ci := range s.BranchCI {
+				conclusion := ci.Conclusion
+				if conclusion == "" {
+					conclusion = "no runs"
+				}
+				branches = append(branches, fmt.Sprintf("%s %s %s", ci.Branch, getStatusIcon(ci.Conclusion), conclusion))
+			}
+			sb.WriteString(fmt.Sprintf("│   CI: %s\n", strings.Join(branches, " │ ")))
+		}
+
+		if s.HasDrift {
+			sb.WriteString(fmt.Sprintf("│   Drift: %s %s\n", getSeverityIcon(s.DriftSeverity), s.DriftSeverity))
+		}
+
 		if s.Repository.Description != "" {
 			sb.WriteString(fmt.Sprintf("│   %s\n", truncate(s.Repository.Description, 60)))
 		}
 
+		if len(s.Errors) > 0 {
+			sb.WriteString(fmt.Sprintf("│   ⚠ Partial status, %d lookup(s) failed (see errors)\n", len(s.Errors)))
+		}
+
 		if i < len(statuses)-1 {
 			sb.WriteString(fmt.Sprintf("├─────────────────────────────────────────────────────────────────┤\n"))
 		}
 	}
 
+	var errs []entity.RepoError
+	for _, s := range statuses {
+		errs = append(errs, s.Errors...)
+	}
+	writeRepoErrors(&sb, errs)
+
 	sb.WriteString(fmt.Sprintf("└─────────────────────────────────────────────────────────────────┘\n"))
 	sb.WriteString(fmt.Sprintf("Total: %d repositories\n", len(statuses)))
 
@@ -168,6 +195,16 @@ func (p *Presenter) FormatCommits(commits []entity.Commit) string {
 }
 
 func (p *Presenter) FormatDriftResults(results []usecase.DriftResult) string {
+	var drifted []usecase.DriftResult
+	var errs []entity.RepoError
+	for _, r := range results {
+		if r.Error != nil {
+			errs = append(errs, *r.Error)
+			continue
+		}
+		drifted = append(drifted, r)
+	}
+
 	if len(results) == 0 {
 		return "No drift detected - all branches are in sync"
 	}
@@ -177,7 +214,11 @@ func (p *Presenter) FormatDriftResults(results []usecase.DriftResult) string {
 	sb.WriteString(fmt.Sprintf("│ BRANCH DRIFT ANALYSIS                                           │\n"))
 	sb.WriteString(fmt.Sprintf("├─────────────────────────────────────────────────────────────────┤\n"))
 
-	for i, r := range results {
+	if len(drifted) == 0 {
+		sb.WriteString(fmt.Sprintf("│ No drift detected in the repositories that could be checked     │\n"))
+	}
+
+	for i, r := range drifted {
 		statusIcon := getDriftStatusIcon(r.Comparison.Status)
 		severityIcon := getSeverityIcon(r.Severity)
 
@@ -191,10 +232,10 @@ func (p *Presenter) FormatDriftResults(results []usecase.DriftResult) string {
 			severityIcon,
 			r.Severity,
 		))
-		sb.WriteString(fmt.Sprintf("│   Ahead: %-3d │ Behind: %-3d │ Files: %-3d              │\n",
+		sb.WriteString(fmt.Sprintf("│   Ahead: %-3d │ Behind: %-3d │ Files: %-4s             │\n",
 			r.Comparison.AheadBy,
 			r.Comparison.BehindBy,
-			len(r.Comparison.Files),
+			fileCount(r.Comparison),
 		))
 
 		if len(r.Actions) > 0 {
@@ -204,17 +245,67 @@ func (p *Presenter) FormatDriftResults(results []usecase.DriftResult) string {
 			}
 		}
 
-		if i < len(results)-1 {
+		if i < len(drifted)-1 {
 			sb.WriteString(fmt.Sprintf("├─────────────────────────────────────────────────────────────────┤\n"))
 		}
 	}
 
+	writeRepoErrors(&sb, errs)
+
 	sb.WriteString(fmt.Sprintf("└─────────────────────────────────────────────────────────────────┘\n"))
-	sb.WriteString(fmt.Sprintf("Total: %d repositories with drift\n", len(results)))
+	sb.WriteString(fmt.Sprintf("Total: %d repositories with drift\n", len(drifted)))
+	if len(errs) > 0 {
+		sb.WriteString(fmt.Sprintf("Could not check: %d repositories\n", len(errs)))
+	}
 
 	return sb.String()
 }
 
+// repoErrorKinds is the order error groups are shown in.
+var repoErrorKinds = []struct {
+	kind  entity.ErrorKind
+	title string
+	hint  string
+}{
+	{entity.ErrorAuth, "Auth", "check the token's scopes and org access"},
+	{entity.ErrorNotFound, "Not found", "the repository or resource does not exist or is hidden"},
+	{entity.ErrorRateLimit, "Rate limit", "retry after the rate limit resets"},
+	{entity.ErrorMissingBranch, "Missing branch", "check prod_branch/dev_branch in repos.json"},
+	{entity.ErrorUnsupported, "Unsupported", "the repository's provider has no such feature"},
+	{entity.ErrorOther, "Other", ""},
+}
+
+// writeRepoErrors appends an errors section grouping errs by kind.
+func writeRepoErrors(sb *strings.Builder, errs []entity.RepoError) {
+	if len(errs) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("├─────────────────────────────────────────────────────────────────┤\n"))
+	sb.WriteString(fmt.Sprintf("│ ERRORS (%d)\n", len(errs)))
+
+	for _, k := range repoErrorKinds {
+		var group []entity.RepoError
+		for _, e := range errs {
+			if e.Kind == k.kind {
+				group = append(group, e)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+
+		if k.hint != "" {
+			sb.WriteString(fmt.Sprintf("│   %s (%d) - %s\n", k.title, len(group), k.hint))
+		} else {
+			sb.WriteString(fmt.Sprintf("│   %s (%d)\n", k.title, len(group)))
+		}
+		for _, e := range group {
+			sb.WriteString(fmt.Sprintf("│     ✗ %s: %s: %s\n", e.Repository, e.Operation, truncate(e.Err.Error(), 60)))
+		}
+	}
+}
+
 func (p *Presenter) FormatRollbackResult(result *entity.RollbackResult) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("┌─────────────────────────────────────────────────────────────────┐\n"))
@@ -229,18 +320,82 @@ func (p *Presenter) FormatRollbackResult(result *entity.RollbackResult) string {
 	sb.WriteString(fmt.Sprintf("│ %s Strategy: %-52s │\n", status, result.Strategy))
 	sb.WriteString(fmt.Sprintf("│   %s\n", result.Message))
 
+	if result.Decision != nil {
+		sb.WriteString("│   Selected automatically because:\n")
+		for _, reason := range result.Decision.Reasons {
+			sb.WriteString(fmt.Sprintf("│     → %s\n", reason))
+		}
+	}
+
 	if result.RunURL != "" {
 		sb.WriteString(fmt.Sprintf("│   Run: %s\n", result.RunURL))
 	}
+	if result.Conclusion != "" {
+		sb.WriteString(fmt.Sprintf("│   Conclusion: %s %s │ Duration: %s\n",
+			getStatusIcon(result.Conclusion),
+			result.Conclusion,
+			result.Duration.Round(time.Second),
+		))
+	}
 	if result.PRURL != "" {
 		sb.WriteString(fmt.Sprintf("│   PR: %s\n", result.PRURL))
 	}
+	if result.Branch != "" {
+		sb.WriteString(fmt.Sprintf("│   Branch: %s\n", result.Branch))
+	}
+	if result.Plan != nil {
+		writeRollbackPlan(&sb, result.Plan)
+	}
 
 	sb.WriteString(fmt.Sprintf("└─────────────────────────────────────────────────────────────────┘\n"))
 
 	return sb.String()
 }
 
+func writeRollbackPlan(sb *strings.Builder, plan *entity.RollbackPlan) {
+	sb.WriteString("├─────────────────────────────────────────────────────────────────┤\n")
+	sb.WriteString("│ PLAN                                                            │\n")
+
+	if plan.Run != nil {
+		sb.WriteString(fmt.Sprintf("│   Run: %d │ %s │ attempt %d\n", plan.Run.ID, truncate(plan.Run.Name, 30), plan.Run.RunAttempt))
+		sb.WriteString(fmt.Sprintf("│   Branch: %s │ SHA: %.7s\n", plan.Run.HeadBranch, plan.Run.HeadSHA))
+		sb.WriteString(fmt.Sprintf("│   Status: %s │ Conclusion: %s %s\n",
+			plan.Run.Status,
+			getStatusIcon(plan.Run.Conclusion),
+			plan.Run.Conclusion,
+		))
+	}
+	if plan.Workflow != nil {
+		sb.WriteString(fmt.Sprintf("│   Workflow: %s (%s)\n", plan.Workflow.Name, plan.Workflow.Path))
+		sb.WriteString(fmt.Sprintf("│   Ref: %s │ State: %s\n", plan.Ref, plan.Workflow.State))
+		if len(plan.Inputs) > 0 {
+			keys := make([]string, 0, len(plan.Inputs))
+			for k := range plan.Inputs {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			sb.WriteString("│   Inputs:\n")
+			for _, k := range keys {
+				sb.WriteString(fmt.Sprintf("│     %s = %s\n", k, plan.Inputs[k]))
+			}
+		}
+	}
+	if plan.Commit != nil {
+		firstLine := strings.Split(plan.Commit.Message, "\n")[0]
+		sb.WriteString(fmt.Sprintf("│   Commit: %.7s %s\n", plan.Commit.SHA, truncate(firstLine, 50)))
+		sb.WriteString(fmt.Sprintf("│   %s → %s\n", plan.Branch, plan.BaseBranch))
+		if len(plan.Commit.Files) > 0 {
+			sb.WriteString(fmt.Sprintf("│   Files (%d):\n", len(plan.Commit.Files)))
+			for _, f := range plan.Commit.Files {
+				sb.WriteString(fmt.Sprintf("│     %-9s %s\n", f.Status, truncate(f.Filename, 50)))
+			}
+		}
+	}
+	for _, w := range plan.Warnings {
+		sb.WriteString(fmt.Sprintf("│   ⚠ %s\n", w))
+	}
+}
+
 func (p *Presenter) FormatSyncPRResult(result *entity.SyncPRResult) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("┌─────────────────────────────────────────────────────────────────┐\n"))
@@ -360,6 +515,69 @@ func (p *Presenter) FormatCreatePRResult(result *usecase.CreatePRResult) string
 	return sb.String()
 }
 
+func (p *Presenter) FormatServerStatus(status *entity.ServerStatus) string {
+	var sb strings.Builder
+	sb.WriteString("┌─────────────────────────────────────────────────────────────────┐\n")
+	sb.WriteString("│ SERVER STATUS                                                   │\n")
+	sb.WriteString("├─────────────────────────────────────────────────────────────────┤\n")
+
+	if status.UserError != "" {
+		sb.WriteString(fmt.Sprintf("│ ✗ User: %s\n", status.UserError))
+	} else {
+		sb.WriteString(fmt.Sprintf("│ ✓ User: %s\n", status.User))
+	}
+
+	sb.WriteString("├─────────────────────────────────────────────────────────────────┤\n")
+	sb.WriteString("│ RATE LIMIT\n")
+	rateLimit := status.RateLimit
+	icon := "✓"
+	if rateLimit.Remaining <= rateLimit.Threshold {
+		icon = "⚠"
+	}
+	sb.WriteString(fmt.Sprintf("│   %s Remaining: %d (requests wait at %d)\n", icon, rateLimit.Remaining, rateLimit.Threshold))
+	if !rateLimit.ResetAt.IsZero() {
+		sb.WriteString(fmt.Sprintf("│   Resets: %s\n", formatTime(rateLimit.ResetAt)))
+	}
+
+	sb.WriteString("├─────────────────────────────────────────────────────────────────┤\n")
+	sb.WriteString("│ RETRIES\n")
+	sb.WriteString(fmt.Sprintf("│   Retried: %d │ Recovered: %d │ Gave up: %d\n",
+		status.Retries.Retries,
+		status.Retries.Recovered,
+		status.Retries.Exhausted,
+	))
+
+	sb.WriteString("├─────────────────────────────────────────────────────────────────┤\n")
+	sb.WriteString("│ CACHE\n")
+	cacheStats := status.Cache
+	sb.WriteString(fmt.Sprintf("│   Entries: %d (%s)\n", cacheStats.Entries, formatBytes(cacheStats.Bytes)))
+	hitRate := 0.0
+	if lookups := cacheStats.Hits + cacheStats.Misses; lookups > 0 {
+		hitRate = float64(cacheStats.Hits) / float64(lookups) * 100
+	}
+	sb.WriteString(fmt.Sprintf("│   Hits: %d │ Misses: %d │ Hit rate: %.0f%% │ Evictions: %d\n",
+		cacheStats.Hits,
+		cacheStats.Misses,
+		hitRate,
+		cacheStats.Evictions,
+	))
+
+	sb.WriteString("└─────────────────────────────────────────────────────────────────┘\n")
+
+	return sb.String()
+}
+
+func (p *Presenter) FormatClearCacheResult(result *usecase.ClearCacheResult) string {
+	var sb strings.Builder
+	sb.WriteString("┌─────────────────────────────────────────────────────────────────┐\n")
+	sb.WriteString("│ CACHE CLEAR RESULT                                              │\n")
+	sb.WriteString("├─────────────────────────────────────────────────────────────────┤\n")
+	sb.WriteString(fmt.Sprintf("│ ✓ Cleared %s: %d entries removed\n", result.Scope, result.Removed))
+	sb.WriteString("└─────────────────────────────────────────────────────────────────┘\n")
+
+	return sb.String()
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -367,6 +585,26 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// fileCount formats the number of changed files, marking counts GitHub cut
+// short.
+func fileCount(comparison entity.BranchComparison) string {
+	if comparison.FilesTruncated {
+		return fmt.Sprintf("%d+", len(comparison.Files))
+	}
+	return fmt.Sprintf("%d", len(comparison.Files))
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
 func formatTime(t time.Time) string {
 	return t.Format("2006-01-02 15:04")
 }
@@ -27,20 +27,29 @@ func NewServer(handler *Handler) *Server {
 
 func (s *Server) registerTools() {
 	s.mcpServer.AddTool(
-		mcp.NewTool("repo_list_status",
-			mcp.WithDescription("List all repositories with their status including open PRs, CI status, and last activity"),
+		readTool("repo_list_status",
+			mcp.WithDescription("List all repositories with their status including open PRs, CI status on the prod and dev branches, drift, last activity and a health score"),
 			mcp.WithString("filter",
 				mcp.Description("Filter repositories by name (partial match)"),
 			),
 			mcp.WithBoolean("archived",
 				mcp.Description("Include archived repositories"),
 			),
+			mcp.WithString("sort",
+				mcp.Description("Sort order: name (default) or health (least healthy first)"),
+			),
+			mcp.WithNumber("min_health",
+				mcp.Description("Only show repositories with a health score of at least this (0-100)"),
+			),
+			mcp.WithNumber("max_health",
+				mcp.Description("Only show repositories with a health score of at most this (0-100)"),
+			),
 		),
 		s.handler.HandleListStatus,
 	)
 
 	s.mcpServer.AddTool(
-		mcp.NewTool("repo_list_prs",
+		readTool("repo_list_prs",
 			mcp.WithDescription("List open pull requests across repositories"),
 			mcp.WithString("repo",
 				mcp.Description("Filter by repository (owner/repo format)"),
@@ -56,7 +65,7 @@ func (s *Server) registerTools() {
 	)
 
 	s.mcpServer.AddTool(
-		mcp.NewTool("repo_check_ci",
+		readTool("repo_check_ci",
 			mcp.WithDescription("Check GitHub Actions CI/CD status for a repository"),
 			mcp.WithString("repo",
 				mcp.Description("Repository in owner/repo format"),
@@ -83,27 +92,33 @@ func (s *Server) registerTools() {
 				mcp.Required(),
 			),
 			mcp.WithString("strategy",
-				mcp.Description("Rollback strategy: rerun (re-run failed workflow), revert (create revert commit), workflow (trigger rollback workflow)"),
+				mcp.Description("Rollback strategy: rerun (re-run failed workflow), revert (create revert commit), workflow (trigger rollback workflow), auto (choose from recent CI runs and commits on the prod branch)"),
 				mcp.Required(),
 			),
 			mcp.WithNumber("workflow_id",
-				mcp.Description("Workflow ID for 'workflow' strategy"),
+				mcp.Description("Workflow ID for 'workflow' strategy, overrides the repo's rollback workflow in repos.json (also lets 'auto' choose the workflow strategy)"),
 			),
 			mcp.WithNumber("run_id",
 				mcp.Description("Run ID for 'rerun' strategy (uses latest if not specified)"),
 			),
 			mcp.WithString("commit_sha",
-				mcp.Description("Commit SHA for 'revert' strategy"),
+				mcp.Description("Commit SHA for 'revert' strategy (opens a revert PR against the prod branch)"),
 			),
 			mcp.WithBoolean("dry_run",
 				mcp.Description("Preview the rollback without executing"),
 			),
+			mcp.WithBoolean("wait",
+				mcp.Description("For 'rerun' and 'workflow': wait for the run to finish and report its conclusion"),
+			),
+			mcp.WithNumber("timeout_seconds",
+				mcp.Description("Maximum time to wait when 'wait' is set (default: 600)"),
+			),
 		),
 		s.handler.HandleTriggerRollback,
 	)
 
 	s.mcpServer.AddTool(
-		mcp.NewTool("repo_recent_commits",
+		readTool("repo_recent_commits",
 			mcp.WithDescription("List recent commits across repositories"),
 			mcp.WithString("repo",
 				mcp.Description("Filter by repository (owner/repo format)"),
@@ -122,7 +137,7 @@ func (s *Server) registerTools() {
 	)
 
 	s.mcpServer.AddTool(
-		mcp.NewTool("repo_check_drift",
+		readTool("repo_check_drift",
 			mcp.WithDescription("Detect differences between production and development branches"),
 			mcp.WithString("repo",
 				mcp.Description("Repository in owner/repo format (checks all repos if not specified)"),
@@ -227,6 +242,40 @@ func (s *Server) registerTools() {
 		),
 		s.handler.HandleDeleteBranch,
 	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool("repo_server_status",
+			mcp.WithDescription("Show the authenticated GitHub user, remaining API rate limit, retry counters and cache statistics"),
+		),
+		s.handler.HandleServerStatus,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool("repo_cache_clear",
+			mcp.WithDescription("Clear cached API data so the next calls fetch fresh results. Clears everything unless repo or namespace is given"),
+			mcp.WithString("repo",
+				mcp.Description("Clear data cached for this repository (owner/repo format)"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Clear one namespace: repos (repository listings), prs (pull requests) or http (responses kept for conditional requests)"),
+			),
+		),
+		s.handler.HandleCacheClear,
+	)
+}
+
+// readTool creates a tool that reads GitHub data, adding the parameters that
+// let callers bypass or limit the age of cached data.
+func readTool(name string, opts ...mcp.ToolOption) mcp.Tool {
+	opts = append(opts,
+		mcp.WithBoolean("fresh",
+			mcp.Description("Bypass the cache and fetch current data from GitHub"),
+		),
+		mcp.WithString("max_age",
+			mcp.Description("Only use cached data younger than this duration (e.g., 30s, 5m)"),
+		),
+	)
+	return mcp.NewTool(name, opts...)
 }
 
 func (s *Server) ServeStdio() error {
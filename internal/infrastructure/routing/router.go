@@ -0,0 +1,391 @@
+// Package routing sends repository calls to one of several clients, so
+// repositories on different accounts or providers share one
+// port.GitHubClient.
+package routing
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// Account is a named client serving the repositories listed in Repositories
+// and those whose owner matches one of its patterns.
+type Account struct {
+	Name         string
+	Repositories []string // owner/repo names served regardless of owner patterns
+	Owners       []string // Owner patterns in path.Match syntax, e.g. acme or acme-*; matched case-insensitively
+	Client       port.GitHubClient
+}
+
+// serves reports whether the account lists the repository by name.
+func (a Account) serves(repository string) bool {
+	return slices.ContainsFunc(a.Repositories, func(name string) bool {
+		return strings.EqualFold(name, repository)
+	})
+}
+
+// matches reports whether one of the account's owner patterns matches owner.
+func (a Account) matches(owner string) bool {
+	owner = strings.ToLower(owner)
+	for _, pattern := range a.Owners {
+		if ok, _ := path.Match(strings.ToLower(pattern), owner); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Router is a port.GitHubClient that sends each call to the account serving
+// the repository. An account listing the repository by name wins; otherwise
+// accounts are tried in order and the first owner pattern match wins, so a
+// catch-all "*" account goes last.
+type Router struct {
+	accounts []Account
+	logger   *logging.Logger
+}
+
+// NewRouter creates a router over accounts, in order of precedence.
+func NewRouter(accounts []Account, logger *logging.Logger) *Router {
+	return &Router{
+		accounts: accounts,
+		logger:   logger.WithComponent("router"),
+	}
+}
+
+// route returns the index of the account serving owner/repo, or -1.
+func (r *Router) route(owner, repo string) int {
+	repository := owner + "/" + repo
+	for i, account := range r.accounts {
+		if account.serves(repository) {
+			return i
+		}
+	}
+	for i, account := range r.accounts {
+		if account.matches(owner) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Router) client(owner, repo string) (port.GitHubClient, error) {
+	i := r.route(owner, repo)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: no account configured for %s/%s", port.ErrUnauthorized, owner, repo)
+	}
+	return r.accounts[i].Client, nil
+}
+
+// clientForRepository routes an owner/repo name.
+func (r *Router) clientForRepository(repository string) (port.GitHubClient, error) {
+	owner, repo, _ := strings.Cut(repository, "/")
+	return r.client(owner, repo)
+}
+
+// ListRepositories merges the listings of every account. A repository is
+// kept from the account that serves it, so one visible to several tokens is
+// listed once and later calls on it reach the same account. An account that
+// fails is left out and reported in a *port.PartialError returned with the
+// other accounts' repositories; the call only fails when every account does.
+func (r *Router) ListRepositories(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+	seen := make(map[string]bool)
+	var all []entity.Repository
+	var errs []error
+
+	for i, account := range r.accounts {
+		repos, err := account.Client.ListRepositories(ctx, filter, includeArchived)
+		if err != nil {
+			r.logger.Warn("skipping account in repository listing", "account", account.Name, "error", err.Error())
+			errs = append(errs, fmt.Errorf("account %s: %w", account.Name, err))
+			continue
+		}
+
+		for _, repo := range repos {
+			key := strings.ToLower(repo.FullName)
+			if seen[key] {
+				continue
+			}
+			owner, name, _ := strings.Cut(repo.FullName, "/")
+			if r.route(owner, name) != i {
+				continue
+			}
+			seen[key] = true
+			all = append(all, repo)
+		}
+	}
+
+	if len(errs) > 0 && len(errs) == len(r.accounts) {
+		return nil, errs[0]
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].UpdatedAt.After(all[j].UpdatedAt)
+	})
+
+	r.logger.Debug("listed repositories", "accounts", len(r.accounts), "failed", len(errs), "count", len(all))
+	if len(errs) > 0 {
+		return all, &port.PartialError{Errs: errs}
+	}
+	return all, nil
+}
+
+func (r *Router) GetRepository(ctx context.Context, owner, repo string) (*entity.Repository, error) {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetRepository(ctx, owner, repo)
+}
+
+// ListPullRequests lists one repository through its account, or with no
+// repository set, goes through the merged repository listing.
+func (r *Router) ListPullRequests(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+	if filter.Repository != "" {
+		client, err := r.clientForRepository(filter.Repository)
+		if err != nil {
+			return nil, err
+		}
+		return client.ListPullRequests(ctx, filter)
+	}
+
+	limit := 30
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+	return acrossRepositories(ctx, r, limit, func(repository string) ([]entity.PullRequest, error) {
+		perRepo := filter
+		perRepo.Repository = repository
+		perRepo.Limit = limit
+		return r.ListPullRequests(ctx, perRepo)
+	})
+}
+
+func (r *Router) GetPullRequest(ctx context.Context, owner, repo string, number int) (*entity.PullRequest, error) {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetPullRequest(ctx, owner, repo, number)
+}
+
+func (r *Router) CreatePullRequest(ctx context.Context, owner, repo, title, body, head, base string, draft bool) (*entity.PullRequest, error) {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return client.CreatePullRequest(ctx, owner, repo, title, body, head, base, draft)
+}
+
+func (r *Router) MergePullRequest(ctx context.Context, owner, repo string, number int, method, commitTitle string) (*entity.MergeResult, error) {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return client.MergePullRequest(ctx, owner, repo, number, method, commitTitle)
+}
+
+// ListCommits lists one repository through its account, or with no
+// repository set, goes through the merged repository listing.
+func (r *Router) ListCommits(ctx context.Context, filter entity.CommitFilter) ([]entity.Commit, error) {
+	if filter.Repository != "" {
+		client, err := r.clientForRepository(filter.Repository)
+		if err != nil {
+			return nil, err
+		}
+		return client.ListCommits(ctx, filter)
+	}
+
+	limit := 30
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+	return acrossRepositories(ctx, r, limit, func(repository string) ([]entity.Commit, error) {
+		perRepo := filter
+		perRepo.Repository = repository
+		perRepo.Limit = limit
+		return r.ListCommits(ctx, perRepo)
+	})
+}
+
+// acrossRepositories collects list's results for each listed repository
+// until limit items were found. Repositories that fail are skipped, as the
+// client does for cross-repository listings. Accounts that could not be
+// listed are reported with the results in the listing's *port.PartialError.
+func acrossRepositories[T any](ctx context.Context, r *Router, limit int, list func(repository string) ([]T, error)) ([]T, error) {
+	repos, listErr := r.ListRepositories(ctx, "", false)
+	if listErr != nil && !port.IsPartial(listErr) {
+		return nil, listErr
+	}
+
+	var all []T
+	for _, repo := range repos {
+		items, err := list(repo.FullName)
+		if err != nil {
+			continue
+		}
+		all = append(all, items...)
+		if len(all) >= limit {
+			return all[:limit], listErr
+		}
+	}
+	return all, listErr
+}
+
+func (r *Router) GetCommit(ctx context.Context, owner, repo, sha string) (*entity.Commit, error) {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetCommit(ctx, owner, repo, sha)
+}
+
+func (r *Router) ListWorkflowRuns(ctx context.Context, filter entity.CIFilter) ([]entity.WorkflowRun, error) {
+	client, err := r.clientForRepository(filter.Repository)
+	if err != nil {
+		return nil, err
+	}
+	return client.ListWorkflowRuns(ctx, filter)
+}
+
+func (r *Router) GetWorkflowRun(ctx context.Context, owner, repo string, runID int64) (*entity.WorkflowRun, error) {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetWorkflowRun(ctx, owner, repo, runID)
+}
+
+func (r *Router) GetWorkflow(ctx context.Context, owner, repo, workflowID string) (*entity.Workflow, error) {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetWorkflow(ctx, owner, repo, workflowID)
+}
+
+func (r *Router) RerunWorkflow(ctx context.Context, owner, repo string, runID int64) error {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return err
+	}
+	return client.RerunWorkflow(ctx, owner, repo, runID)
+}
+
+func (r *Router) TriggerWorkflow(ctx context.Context, owner, repo, workflowID, ref string, inputs map[string]string) error {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return err
+	}
+	return client.TriggerWorkflow(ctx, owner, repo, workflowID, ref, inputs)
+}
+
+func (r *Router) CompareBranches(ctx context.Context, owner, repo, base, head string) (*entity.BranchComparison, error) {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return client.CompareBranches(ctx, owner, repo, base, head)
+}
+
+func (r *Router) CreateBranch(ctx context.Context, owner, repo, branch, fromBranch string) error {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return err
+	}
+	return client.CreateBranch(ctx, owner, repo, branch, fromBranch)
+}
+
+func (r *Router) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return err
+	}
+	return client.DeleteBranch(ctx, owner, repo, branch)
+}
+
+func (r *Router) CheckRevert(ctx context.Context, owner, repo string) error {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return err
+	}
+	return client.CheckRevert(ctx, owner, repo)
+}
+
+func (r *Router) RevertCommit(ctx context.Context, owner, repo, branch, sha string) (*entity.Commit, error) {
+	client, err := r.client(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return client.RevertCommit(ctx, owner, repo, branch, sha)
+}
+
+// GetCurrentUser returns the login of each account, as name=login pairs when
+// there are several. An account that fails is logged and shown as
+// unavailable; the call only fails when every account does.
+func (r *Router) GetCurrentUser(ctx context.Context) (string, error) {
+	if len(r.accounts) == 1 {
+		return r.accounts[0].Client.GetCurrentUser(ctx)
+	}
+
+	logins := make([]string, 0, len(r.accounts))
+	var firstErr error
+	failed := 0
+	for _, account := range r.accounts {
+		login, err := account.Client.GetCurrentUser(ctx)
+		if err != nil {
+			r.logger.Warn("failed to get current user of account", "account", account.Name, "error", err.Error())
+			if firstErr == nil {
+				firstErr = fmt.Errorf("account %s: %w", account.Name, err)
+			}
+			failed++
+			logins = append(logins, account.Name+"=unavailable")
+			continue
+		}
+		logins = append(logins, account.Name+"="+login)
+	}
+	if failed == len(r.accounts) {
+		return "", firstErr
+	}
+	return strings.Join(logins, ", "), nil
+}
+
+// RateLimitStatus implements port.APIStatusProvider, reporting the account
+// closest to its limit.
+func (r *Router) RateLimitStatus() entity.RateLimitStatus {
+	var status entity.RateLimitStatus
+	found := false
+	for _, account := range r.accounts {
+		provider, ok := account.Client.(port.APIStatusProvider)
+		if !ok {
+			continue
+		}
+		if s := provider.RateLimitStatus(); !found || s.Remaining < status.Remaining {
+			status, found = s, true
+		}
+	}
+	return status
+}
+
+// RetryStats implements port.APIStatusProvider, summing every account.
+func (r *Router) RetryStats() entity.RetryStats {
+	var stats entity.RetryStats
+	for _, account := range r.accounts {
+		provider, ok := account.Client.(port.APIStatusProvider)
+		if !ok {
+			continue
+		}
+		s := provider.RetryStats()
+		stats.Retries += s.Retries
+		stats.Recovered += s.Recovered
+		stats.Exhausted += s.Exhausted
+	}
+	return stats
+}
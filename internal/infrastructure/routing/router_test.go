@@ -0,0 +1,236 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/carlos/mcp-repo-monitor/internal/application/port"
+	"github.com/carlos/mcp-repo-monitor/internal/domain/entity"
+	"github.com/carlos/mcp-repo-monitor/internal/infrastructure/logging"
+)
+
+// listing returns a mock client that lists repos, each updated an hour
+// apart in the given order.
+func listing(repos ...string) *port.MockGitHubClient {
+	return &port.MockGitHubClient{
+		ListRepositoriesFunc: func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+			result := make([]entity.Repository, len(repos))
+			for i, name := range repos {
+				result[i] = entity.Repository{FullName: name, UpdatedAt: time.Unix(0, 0).Add(time.Duration(len(repos)-i) * time.Hour)}
+			}
+			return result, nil
+		},
+	}
+}
+
+func TestRouter_RoutesByOwner(t *testing.T) {
+	acme := &port.MockGitHubClient{}
+	personal := &port.MockGitHubClient{}
+	router := NewRouter([]Account{
+		{Name: "acme", Owners: []string{"acme", "acme-*"}, Client: acme},
+		{Name: "personal", Owners: []string{"carlos"}, Client: personal},
+	}, logging.NewNoop())
+
+	tests := []struct {
+		owner string
+		want  *port.MockGitHubClient
+	}{
+		{"acme", acme},
+		{"ACME-labs", acme},
+		{"carlos", personal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.owner, func(t *testing.T) {
+			if _, err := router.GetPullRequest(context.Background(), tt.owner, "api", 1); err != nil {
+				t.Fatalf("GetPullRequest: %v", err)
+			}
+			calls := tt.want.GetPullRequestCalls
+			if len(calls) == 0 || calls[len(calls)-1].Owner != tt.owner {
+				t.Errorf("call for %s did not reach its account", tt.owner)
+			}
+		})
+	}
+
+	_, err := router.GetPullRequest(context.Background(), "globex", "api", 1)
+	if !errors.Is(err, port.ErrUnauthorized) {
+		t.Errorf("GetPullRequest(globex) error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestRouter_RepositoriesWinOverOwners(t *testing.T) {
+	gitlab := listing("acme/infra", "acme/api")
+	github := listing("acme/api", "acme/infra", "acme/web")
+	router := NewRouter([]Account{
+		{Name: "gitlab", Repositories: []string{"Acme/Infra"}, Client: gitlab},
+		{Name: "default", Owners: []string{"*"}, Client: github},
+	}, logging.NewNoop())
+	ctx := context.Background()
+
+	if _, err := router.GetPullRequest(ctx, "acme", "infra", 1); err != nil {
+		t.Fatalf("GetPullRequest(acme/infra): %v", err)
+	}
+	if _, err := router.GetPullRequest(ctx, "acme", "api", 1); err != nil {
+		t.Fatalf("GetPullRequest(acme/api): %v", err)
+	}
+	if len(gitlab.GetPullRequestCalls) != 1 || gitlab.GetPullRequestCalls[0].Repo != "infra" {
+		t.Errorf("gitlab calls = %v, want only acme/infra", gitlab.GetPullRequestCalls)
+	}
+	if len(github.GetPullRequestCalls) != 1 || github.GetPullRequestCalls[0].Repo != "api" {
+		t.Errorf("github calls = %v, want only acme/api", github.GetPullRequestCalls)
+	}
+
+	repos, err := router.ListRepositories(ctx, "", false)
+	if err != nil {
+		t.Fatalf("ListRepositories: %v", err)
+	}
+	if len(repos) != 3 {
+		t.Errorf("ListRepositories() = %v, want each repository once", repos)
+	}
+}
+
+func TestRouter_ListRepositoriesMergesAccounts(t *testing.T) {
+	router := NewRouter([]Account{
+		{Name: "acme", Owners: []string{"acme"}, Client: listing("acme/api", "carlos/dotfiles")},
+		{Name: "personal", Owners: []string{"*"}, Client: listing("carlos/dotfiles", "acme/api", "acme/web")},
+	}, logging.NewNoop())
+
+	repos, err := router.ListRepositories(context.Background(), "", false)
+	if err != nil {
+		t.Fatalf("ListRepositories: %v", err)
+	}
+
+	got := make(map[string]int)
+	for _, repo := range repos {
+		got[repo.FullName]++
+	}
+	want := map[string]int{"acme/api": 1, "carlos/dotfiles": 1}
+	if !maps.Equal(got, want) {
+		t.Errorf("ListRepositories() = %v, want %v", got, want)
+	}
+	for i := 1; i < len(repos); i++ {
+		if repos[i].UpdatedAt.After(repos[i-1].UpdatedAt) {
+			t.Errorf("ListRepositories() not sorted by update time: %v", repos)
+		}
+	}
+}
+
+func TestRouter_ListRepositoriesSkipsFailingAccount(t *testing.T) {
+	failing := &port.MockGitHubClient{
+		ListRepositoriesFunc: func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+			return nil, port.ErrUnauthorized
+		},
+	}
+	router := NewRouter([]Account{
+		{Name: "acme", Owners: []string{"acme"}, Client: failing},
+		{Name: "personal", Owners: []string{"*"}, Client: listing("carlos/dotfiles")},
+	}, logging.NewNoop())
+
+	repos, err := router.ListRepositories(context.Background(), "", false)
+	if !port.IsPartial(err) || !errors.Is(err, port.ErrUnauthorized) {
+		t.Errorf("ListRepositories() error = %v, want a partial error naming acme's failure", err)
+	}
+	if len(repos) != 1 || repos[0].FullName != "carlos/dotfiles" {
+		t.Errorf("ListRepositories() = %v, want [carlos/dotfiles]", repos)
+	}
+}
+
+func TestRouter_ListRepositoriesFailsWhenEveryAccountFails(t *testing.T) {
+	failing := &port.MockGitHubClient{
+		ListRepositoriesFunc: func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+			return nil, port.ErrUnauthorized
+		},
+	}
+	router := NewRouter([]Account{
+		{Name: "acme", Owners: []string{"acme"}, Client: failing},
+		{Name: "personal", Owners: []string{"*"}, Client: failing},
+	}, logging.NewNoop())
+
+	_, err := router.ListRepositories(context.Background(), "", false)
+	if !errors.Is(err, port.ErrUnauthorized) {
+		t.Errorf("ListRepositories() error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestRouter_GetCurrentUserSkipsFailingAccount(t *testing.T) {
+	user := func(login string, err error) *port.MockGitHubClient {
+		return &port.MockGitHubClient{
+			GetCurrentUserFunc: func(ctx context.Context) (string, error) {
+				return login, err
+			},
+		}
+	}
+
+	router := NewRouter([]Account{
+		{Name: "acme", Owners: []string{"acme"}, Client: user("", port.ErrUnauthorized)},
+		{Name: "personal", Owners: []string{"*"}, Client: user("carlos", nil)},
+	}, logging.NewNoop())
+
+	got, err := router.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentUser() error = %v", err)
+	}
+	if want := "acme=unavailable, personal=carlos"; got != want {
+		t.Errorf("GetCurrentUser() = %q, want %q", got, want)
+	}
+
+	router = NewRouter([]Account{
+		{Name: "acme", Owners: []string{"acme"}, Client: user("", port.ErrUnauthorized)},
+		{Name: "personal", Owners: []string{"*"}, Client: user("", port.ErrUnauthorized)},
+	}, logging.NewNoop())
+
+	if _, err := router.GetCurrentUser(context.Background()); !errors.Is(err, port.ErrUnauthorized) {
+		t.Errorf("GetCurrentUser() error = %v, want ErrUnauthorized when every account fails", err)
+	}
+}
+
+func TestRouter_ListPullRequestsAcrossAccounts(t *testing.T) {
+	prsOf := func(client *port.MockGitHubClient) *port.MockGitHubClient {
+		client.ListPullRequestsFunc = func(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+			if filter.Repository == "" {
+				t.Errorf("account asked for a cross-repository listing")
+			}
+			return []entity.PullRequest{{Number: 1, Repository: filter.Repository}}, nil
+		}
+		return client
+	}
+	router := NewRouter([]Account{
+		{Name: "acme", Owners: []string{"acme"}, Client: prsOf(listing("acme/api"))},
+		{Name: "personal", Owners: []string{"*"}, Client: prsOf(listing("carlos/dotfiles", "acme/api"))},
+	}, logging.NewNoop())
+
+	prs, err := router.ListPullRequests(context.Background(), entity.PRFilter{})
+	if err != nil {
+		t.Fatalf("ListPullRequests: %v", err)
+	}
+	if len(prs) != 2 {
+		t.Errorf("ListPullRequests() = %v, want one PR per repository", prs)
+	}
+}
+
+func TestRouter_ListPullRequestsReportsFailingAccount(t *testing.T) {
+	failing := &port.MockGitHubClient{
+		ListRepositoriesFunc: func(ctx context.Context, filter string, includeArchived bool) ([]entity.Repository, error) {
+			return nil, port.ErrRateLimited
+		},
+	}
+	personal := listing("carlos/dotfiles")
+	personal.ListPullRequestsFunc = func(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+		return []entity.PullRequest{{Number: 1, Repository: filter.Repository}}, nil
+	}
+	router := NewRouter([]Account{
+		{Name: "acme", Owners: []string{"acme"}, Client: failing},
+		{Name: "personal", Owners: []string{"*"}, Client: personal},
+	}, logging.NewNoop())
+
+	prs, err := router.ListPullRequests(context.Background(), entity.PRFilter{})
+	if !port.IsPartial(err) || !errors.Is(err, port.ErrRateLimited) {
+		t.Errorf("ListPullRequests() error = %v, want a partial error naming acme's failure", err)
+	}
+	if len(prs) != 1 {
+		t.Errorf("ListPullRequests() = %v, want the personal account's PR", prs)
+	}
+}